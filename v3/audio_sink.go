@@ -0,0 +1,379 @@
+package vlc
+
+/*
+#cgo LDFLAGS: -lvlc
+#include <vlc/vlc.h>
+#include <stdbool.h>
+#include <stdlib.h>
+#include <string.h>
+
+extern void audioPlayCB(void* opaque, void* samples, unsigned count, int64_t pts);
+extern void audioPauseCB(void* opaque, int64_t pts);
+extern void audioResumeCB(void* opaque, int64_t pts);
+extern void audioFlushCB(void* opaque, int64_t pts);
+extern void audioDrainCB(void* opaque);
+extern void audioVolumeCB(void* opaque, float volume, int mute);
+extern int audioSetupCB(void** opaque, char* format, unsigned* rate, unsigned* channels);
+extern void audioCleanupCB(void* opaque);
+
+static inline void audioPlay(void* opaque, const void* samples, unsigned count, int64_t pts) {
+	audioPlayCB(opaque, (void*)samples, count, pts);
+}
+
+static inline void audioVolume(void* opaque, float volume, bool mute) {
+	audioVolumeCB(opaque, volume, mute ? 1 : 0);
+}
+
+static inline void audioSetCallbacks(libvlc_media_player_t* player, void* opaque) {
+	libvlc_audio_set_callbacks(player, audioPlay, audioPauseCB, audioResumeCB, audioFlushCB, audioDrainCB, opaque);
+	libvlc_audio_set_volume_callback(player, audioVolume);
+	libvlc_audio_set_format_callbacks(player, audioSetupCB, audioCleanupCB);
+}
+*/
+import "C"
+import (
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// AudioSampleFormat identifies the format of the decoded audio samples
+// delivered to an audio sink.
+type AudioSampleFormat string
+
+// Audio sample formats.
+const (
+	// Signed 16-bit integer samples, in native byte order.
+	AudioSampleFormatS16N AudioSampleFormat = "S16N"
+
+	// 32-bit floating point samples, in native byte order.
+	AudioSampleFormatFL32 AudioSampleFormat = "FL32"
+)
+
+// Validate returns an error if the sample format is not supported by
+// audio sinks.
+func (sf AudioSampleFormat) Validate() error {
+	switch sf {
+	case AudioSampleFormatS16N, AudioSampleFormatFL32:
+		return nil
+	}
+
+	return ErrAudioFormatUnsupported
+}
+
+// SampleSize returns the size of a single sample, in bytes.
+func (sf AudioSampleFormat) SampleSize() int {
+	switch sf {
+	case AudioSampleFormatS16N:
+		return 2
+	case AudioSampleFormatFL32:
+		return 4
+	}
+
+	return 0
+}
+
+// AudioFormat contains information about the format of the audio samples
+// delivered to an audio sink.
+type AudioFormat struct {
+	SampleFormat AudioSampleFormat // Format of the samples.
+	Rate         uint              // Sample rate, in Hz.
+	Channels     uint              // Number of channels.
+}
+
+// FrameSize returns the number of bytes occupied by a sample frame, which
+// contains a sample for each of the channels.
+func (af *AudioFormat) FrameSize() int {
+	return af.SampleFormat.SampleSize() * int(af.Channels)
+}
+
+// AudioBuffer represents a block of decoded audio samples delivered to an
+// audio sink. The samples of the channels are interleaved.
+type AudioBuffer struct {
+	// Format of the samples.
+	Format AudioFormat
+
+	// Raw sample data.
+	Data []byte
+
+	// Number of sample frames contained by the buffer.
+	Frames int
+
+	// Expected play time of the samples. The value is expressed on the
+	// clock of libVLC, not relative to the start of the media.
+	PTS time.Duration
+
+	buf  *[]byte
+	pool *sync.Pool
+}
+
+// Int16s returns the samples of the buffer as signed 16-bit integers.
+// The returned slice shares the data of the buffer. Returns nil if the
+// sample format of the buffer is not AudioSampleFormatS16N.
+func (ab *AudioBuffer) Int16s() []int16 {
+	if ab == nil || ab.Format.SampleFormat != AudioSampleFormatS16N || len(ab.Data) < 2 {
+		return nil
+	}
+
+	count := len(ab.Data) / 2
+	return (*[1 << 28]int16)(unsafe.Pointer(&ab.Data[0]))[:count:count]
+}
+
+// Float32s returns the samples of the buffer as 32-bit floating point
+// numbers. The returned slice shares the data of the buffer. Returns nil if
+// the sample format of the buffer is not AudioSampleFormatFL32.
+func (ab *AudioBuffer) Float32s() []float32 {
+	if ab == nil || ab.Format.SampleFormat != AudioSampleFormatFL32 || len(ab.Data) < 4 {
+		return nil
+	}
+
+	count := len(ab.Data) / 4
+	return (*[1 << 27]float32)(unsafe.Pointer(&ab.Data[0]))[:count:count]
+}
+
+// Release returns the data of the buffer to the pool of the audio sink,
+// in order for it to be reused by subsequent buffers. The buffer must not
+// be used after it is released.
+func (ab *AudioBuffer) Release() {
+	if ab == nil || ab.pool == nil {
+		return
+	}
+
+	*ab.buf = ab.Data[:0]
+	ab.pool.Put(ab.buf)
+	ab.Data, ab.buf, ab.pool = nil, nil, nil
+}
+
+// AudioSink represents a receiver of the decoded audio samples of a player.
+// Use the Player.SetAudioSink method in order to redirect the audio output
+// of a player to an audio sink.
+//
+//	NOTE: The methods of the sink are invoked from the internal threads of
+//	libVLC. They should return as soon as possible and they must not call
+//	libVLC functions.
+type AudioSink interface {
+	// SetupAudio is called when the audio output is started. The provided
+	// format contains the sample format, rate and channel count of the
+	// source audio. The sink can change the fields of the format in order
+	// to request a different format, which is then produced by libVLC.
+	// Returning an error prevents the audio from being played.
+	SetupAudio(format *AudioFormat) error
+
+	// PlayAudio is called when a block of samples must be played. The sink
+	// owns the buffer and should call its Release method when it no longer
+	// needs it.
+	PlayAudio(buf *AudioBuffer)
+
+	// PauseAudio is called when the playback is paused.
+	PauseAudio(pts time.Duration)
+
+	// ResumeAudio is called when the playback is resumed.
+	ResumeAudio(pts time.Duration)
+
+	// FlushAudio is called when the pending samples must be discarded
+	// (e.g. when seeking).
+	FlushAudio(pts time.Duration)
+
+	// DrainAudio is called when the pending samples must be played
+	// before the playback is stopped.
+	DrainAudio()
+
+	// SetAudioVolume is called when the volume or the mute state of the
+	// player changes. The volume is a linear factor, 1.0 meaning 100%.
+	// The samples are not scaled by libVLC when an audio sink is used.
+	SetAudioVolume(volume float32, mute bool)
+
+	// CleanupAudio is called when the audio output is stopped.
+	CleanupAudio()
+}
+
+// SetAudioSink redirects the audio output of the player to the specified
+// audio sink. The sink is used starting with the next audio output, so it
+// should be set before the playback is started.
+//
+//	NOTE: Once set, the audio sink cannot be removed. A different sink
+//	can be set in order to replace it.
+func (p *Player) SetAudioSink(sink AudioSink) error {
+	if err := p.assertInit(); err != nil {
+		return err
+	}
+	if sink == nil {
+		return ErrInvalidAudioSink
+	}
+
+	// Register audio sink context. The player holds a reference to the
+	// context, while each started audio output holds an additional one.
+	id := p.inst.objects.add(&audioSinkContext{sink: sink})
+	C.audioSetCallbacks(p.player, id)
+
+	// Release previous audio sink context.
+	p.inst.objects.decRefs(p.audioSinkID)
+	p.audioSinkID = id
+
+	return nil
+}
+
+type audioSinkContext struct {
+	sync.Mutex
+
+	sink   AudioSink
+	format AudioFormat
+	pool   *sync.Pool
+}
+
+func (ac *audioSinkContext) setup(format *AudioFormat) error {
+	if err := ac.sink.SetupAudio(format); err != nil {
+		return err
+	}
+	if err := format.SampleFormat.Validate(); err != nil {
+		return err
+	}
+	if format.Rate == 0 || format.Channels == 0 {
+		return ErrInvalid
+	}
+
+	ac.Lock()
+	ac.format = *format
+	ac.pool = &sync.Pool{
+		New: func() interface{} {
+			return new([]byte)
+		},
+	}
+	ac.Unlock()
+
+	return nil
+}
+
+func (ac *audioSinkContext) play(samples unsafe.Pointer, count int, pts time.Duration) {
+	ac.Lock()
+	format, pool := ac.format, ac.pool
+	ac.Unlock()
+	if pool == nil {
+		return
+	}
+
+	// Copy samples to a pooled buffer.
+	size := count * format.FrameSize()
+
+	buf := pool.Get().(*[]byte)
+	data := *buf
+	if cap(data) < size {
+		data = make([]byte, size)
+	}
+	data = data[:size]
+	if size > 0 {
+		C.memcpy(unsafe.Pointer(&data[0]), samples, C.size_t(size))
+	}
+
+	ac.sink.PlayAudio(&AudioBuffer{
+		Format: format,
+		Data:   data,
+		Frames: count,
+		PTS:    pts,
+		buf:    buf,
+		pool:   pool,
+	})
+}
+
+func (ac *audioSinkContext) cleanup() {
+	ac.Lock()
+	ac.pool = nil
+	ac.Unlock()
+
+	ac.sink.CleanupAudio()
+}
+
+func getAudioSinkContext(id objectID) (*Instance, *audioSinkContext, bool) {
+	inst, obj, ok := instances.object(id)
+	if !ok {
+		return nil, nil, false
+	}
+
+	ctx, ok := obj.(*audioSinkContext)
+	return inst, ctx, ok
+}
+
+func audioPTS(pts C.int64_t) time.Duration {
+	return time.Duration(pts) * time.Microsecond
+}
+
+//export audioSetupCB
+func audioSetupCB(opaque *unsafe.Pointer, format *C.char, rate, channels *C.uint) C.int {
+	inst, ctx, ok := getAudioSinkContext(*opaque)
+	if !ok {
+		return -1
+	}
+
+	// Negotiate audio format.
+	audioFormat := &AudioFormat{
+		SampleFormat: AudioSampleFormat(C.GoStringN(format, 4)),
+		Rate:         uint(*rate),
+		Channels:     uint(*channels),
+	}
+	if err := ctx.setup(audioFormat); err != nil {
+		return -1
+	}
+
+	cFormat := C.CString(string(audioFormat.SampleFormat))
+	C.memcpy(unsafe.Pointer(format), unsafe.Pointer(cFormat), 4)
+	C.free(unsafe.Pointer(cFormat))
+
+	*rate, *channels = C.uint(audioFormat.Rate), C.uint(audioFormat.Channels)
+
+	// The audio output holds a reference to the context until cleanup.
+	inst.objects.incRefs(*opaque)
+	return 0
+}
+
+//export audioCleanupCB
+func audioCleanupCB(opaque unsafe.Pointer) {
+	inst, ctx, ok := getAudioSinkContext(opaque)
+	if !ok {
+		return
+	}
+
+	ctx.cleanup()
+	inst.objects.decRefs(opaque)
+}
+
+//export audioPlayCB
+func audioPlayCB(opaque, samples unsafe.Pointer, count C.uint, pts C.int64_t) {
+	if _, ctx, ok := getAudioSinkContext(opaque); ok {
+		ctx.play(samples, int(count), audioPTS(pts))
+	}
+}
+
+//export audioPauseCB
+func audioPauseCB(opaque unsafe.Pointer, pts C.int64_t) {
+	if _, ctx, ok := getAudioSinkContext(opaque); ok {
+		ctx.sink.PauseAudio(audioPTS(pts))
+	}
+}
+
+//export audioResumeCB
+func audioResumeCB(opaque unsafe.Pointer, pts C.int64_t) {
+	if _, ctx, ok := getAudioSinkContext(opaque); ok {
+		ctx.sink.ResumeAudio(audioPTS(pts))
+	}
+}
+
+//export audioFlushCB
+func audioFlushCB(opaque unsafe.Pointer, pts C.int64_t) {
+	if _, ctx, ok := getAudioSinkContext(opaque); ok {
+		ctx.sink.FlushAudio(audioPTS(pts))
+	}
+}
+
+//export audioDrainCB
+func audioDrainCB(opaque unsafe.Pointer) {
+	if _, ctx, ok := getAudioSinkContext(opaque); ok {
+		ctx.sink.DrainAudio()
+	}
+}
+
+//export audioVolumeCB
+func audioVolumeCB(opaque unsafe.Pointer, volume C.float, mute C.int) {
+	if _, ctx, ok := getAudioSinkContext(opaque); ok {
+		ctx.sink.SetAudioVolume(float32(volume), mute != 0)
+	}
+}
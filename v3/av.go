@@ -71,11 +71,16 @@ type AudioOutput struct {
 // In order to change the audio output of a media player instance,
 // use the Player.SetAudioOutput method.
 func AudioOutputList() ([]*AudioOutput, error) {
-	if err := inst.assertInit(); err != nil {
+	return inst.AudioOutputList()
+}
+
+// AudioOutputList returns the list of audio outputs available to the instance.
+func (i *Instance) AudioOutputList() ([]*AudioOutput, error) {
+	if err := i.assertInit(); err != nil {
 		return nil, err
 	}
 
-	cOutputs := C.libvlc_audio_output_list_get(inst.handle)
+	cOutputs := C.libvlc_audio_output_list_get(i.handle)
 	if cOutputs == nil {
 		return nil, errOrDefault(getError(), ErrAudioOutputListMissing)
 	}
@@ -108,13 +113,19 @@ type AudioOutputDevice struct {
 //	Some audio output devices in the list might not work in some circumstances.
 //	By default, it is recommended to not specify any explicit audio device.
 func ListAudioOutputDevices(output string) ([]*AudioOutputDevice, error) {
-	if err := inst.assertInit(); err != nil {
+	return inst.ListAudioOutputDevices(output)
+}
+
+// ListAudioOutputDevices returns the list of devices available to the
+// instance for the specified audio output.
+func (i *Instance) ListAudioOutputDevices(output string) ([]*AudioOutputDevice, error) {
+	if err := i.assertInit(); err != nil {
 		return nil, err
 	}
 
 	cOutput := C.CString(output)
 	defer C.free(unsafe.Pointer(cOutput))
-	return parseAudioOutputDeviceList(C.libvlc_audio_output_device_list_get(inst.handle, cOutput))
+	return parseAudioOutputDeviceList(C.libvlc_audio_output_device_list_get(i.handle, cOutput))
 }
 
 func parseAudioOutputDeviceList(cDevices *C.libvlc_audio_output_device_t) ([]*AudioOutputDevice, error) {
@@ -144,20 +155,30 @@ type ModuleDescription struct {
 
 // ListAudioFilters returns the list of available audio filters.
 func ListAudioFilters() ([]*ModuleDescription, error) {
-	if err := inst.assertInit(); err != nil {
+	return inst.ListAudioFilters()
+}
+
+// ListVideoFilters returns the list of available video filters.
+func ListVideoFilters() ([]*ModuleDescription, error) {
+	return inst.ListVideoFilters()
+}
+
+// ListAudioFilters returns the list of audio filters available to the instance.
+func (i *Instance) ListAudioFilters() ([]*ModuleDescription, error) {
+	if err := i.assertInit(); err != nil {
 		return nil, err
 	}
 
-	return parseFilterList(C.libvlc_audio_filter_list_get(inst.handle))
+	return parseFilterList(C.libvlc_audio_filter_list_get(i.handle))
 }
 
-// ListVideoFilters returns the list of available video filters.
-func ListVideoFilters() ([]*ModuleDescription, error) {
-	if err := inst.assertInit(); err != nil {
+// ListVideoFilters returns the list of video filters available to the instance.
+func (i *Instance) ListVideoFilters() ([]*ModuleDescription, error) {
+	if err := i.assertInit(); err != nil {
 		return nil, err
 	}
 
-	return parseFilterList(C.libvlc_video_filter_list_get(inst.handle))
+	return parseFilterList(C.libvlc_video_filter_list_get(i.handle))
 }
 
 func parseFilterList(cFilters *C.libvlc_module_description_t) ([]*ModuleDescription, error) {
@@ -0,0 +1,243 @@
+package vlc
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ExportError is returned when a media export operation (e.g. a clip export
+// or a transcoding job) fails. Use errors.Is on the error in order to check
+// its cause (e.g. context.Canceled, ErrExportFailed).
+type ExportError struct {
+	Op   string // Operation which failed (e.g. "clip export", "transcode").
+	Path string // Output path of the operation.
+	Err  error  // Cause of the failure.
+}
+
+// Error returns the string representation of the error.
+func (e *ExportError) Error() string {
+	return fmt.Sprintf("%s to %q failed: %v", e.Op, e.Path, e.Err)
+}
+
+// Unwrap returns the cause of the error.
+func (e *ExportError) Unwrap() error {
+	return e.Err
+}
+
+// ClipOptions contains the options used to export a clip of a media.
+type ClipOptions struct {
+	// Start and end offsets of the clip. An end offset of 0 exports the
+	// media until its end.
+	Start time.Duration
+	End   time.Duration
+
+	// Output path and container format (e.g. ts, mp4, ogg, webm) of the clip.
+	Path string
+	Mux  string
+
+	// Transcoding settings. If nil, the streams are copied without
+	// being transcoded.
+	Transcode *Transcode
+
+	// Optional progress callback, which receives the percentage of the
+	// clip exported so far, in the [0.0, 1.0] interval.
+	Progress func(progress float32)
+}
+
+// Validate returns an error if the clip options are not valid.
+func (co *ClipOptions) Validate() error {
+	if co.Start < 0 || co.End < 0 || (co.End > 0 && co.End <= co.Start) {
+		return fmt.Errorf("%w: invalid clip interval", ErrInvalid)
+	}
+	if co.Transcode != nil {
+		if err := co.Transcode.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return (&FileOutput{Path: co.Path, Mux: co.Mux}).Validate()
+}
+
+// ExportClip saves the specified time interval of the media to a new file.
+// The media is not modified, as the export is performed using a duplicate
+// of it, which is played by a headless player. The method returns when the
+// export is finished, fails, or when the provided context is done. The
+// returned error is an *ExportError if the export does not succeed.
+//
+//	NOTE: When the streams are copied, the clip boundaries are aligned to the
+//	nearest keyframes. Use transcoding in order to obtain accurate clips.
+func ExportClip(ctx context.Context, m *Media, opts ClipOptions) error {
+	if err := m.assertInit(); err != nil {
+		return err
+	}
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	// Create stream output chain.
+	so := NewStreamOutput()
+	if opts.Transcode != nil {
+		so.Add(opts.Transcode)
+	}
+	so.Add(&FileOutput{Path: opts.Path, Mux: opts.Mux})
+
+	chain, err := so.Render()
+	if err != nil {
+		return err
+	}
+
+	options := []string{
+		":sout=" + chain,
+		":start-time=" + formatSeconds(opts.Start),
+	}
+	if opts.End > 0 {
+		options = append(options, ":stop-time="+formatSeconds(opts.End))
+	}
+
+	// Run export job.
+	job := &exportJob{
+		media:   m,
+		options: options,
+		progress: func(pos float32, length time.Duration) {
+			if opts.Progress == nil || length <= 0 {
+				return
+			}
+
+			end := opts.End
+			if end <= 0 || end > length {
+				end = length
+			}
+			if end <= opts.Start {
+				return
+			}
+
+			current := time.Duration(float64(pos) * float64(length))
+			opts.Progress(clampProgress(float64(current-opts.Start) / float64(end-opts.Start)))
+		},
+	}
+	if err := job.run(ctx); err != nil {
+		return &ExportError{Op: "clip export", Path: opts.Path, Err: err}
+	}
+
+	return nil
+}
+
+// exportJob plays a duplicate of a media, using the specified options,
+// using a headless player, until the end of the media is reached.
+type exportJob struct {
+	media    *Media
+	options  []string
+	progress func(pos float32, length time.Duration)
+
+	stats *MediaStats
+}
+
+func (ej *exportJob) run(ctx context.Context) error {
+	m, err := ej.media.Duplicate()
+	if err != nil {
+		return err
+	}
+	defer m.Release()
+
+	if err := m.AddOptions(ej.options...); err != nil {
+		return err
+	}
+
+	// Create headless player.
+	player, err := m.inst.NewPlayer()
+	if err != nil {
+		return err
+	}
+	defer player.Release()
+
+	if err := player.SetMedia(m); err != nil {
+		return err
+	}
+
+	manager, err := player.EventManager()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events, err := manager.Subscribe(ctx,
+		MediaPlayerPositionChanged,
+		MediaPlayerEndReached,
+		MediaPlayerEncounteredError,
+	)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Stopping the player finalizes the output file. Wait for the
+		// events to be detached before the player is released.
+		player.Stop()
+		cancel()
+		for range events {
+		}
+	}()
+
+	// Start export.
+	if err := player.Play(); err != nil {
+		return err
+	}
+
+	var length time.Duration
+	for {
+		select {
+		case info, ok := <-events:
+			if !ok {
+				return ctx.Err()
+			}
+
+			switch info.Event {
+			case MediaPlayerPositionChanged:
+				payload, ok := info.Payload.(*PositionPayload)
+				if !ok || ej.progress == nil {
+					continue
+				}
+
+				if ms, err := player.MediaLength(); err == nil && ms > 0 {
+					length = time.Duration(ms) * time.Millisecond
+				}
+				ej.progress(payload.Position, length)
+			case MediaPlayerEndReached:
+				stats, err := m.Stats()
+				if err != nil {
+					return err
+				}
+				ej.stats = stats
+
+				if ej.progress != nil {
+					ej.progress(1, length)
+				}
+				return nil
+			case MediaPlayerEncounteredError:
+				// The libVLC error message is thread local, so it is not
+				// available on this goroutine.
+				return fmt.Errorf("%w: player encountered an error (MediaPlayerEncounteredError)", ErrExportFailed)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+}
+
+func clampProgress(progress float64) float32 {
+	switch {
+	case progress < 0:
+		return 0
+	case progress > 1:
+		return 1
+	}
+
+	return float32(progress)
+}
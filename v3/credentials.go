@@ -0,0 +1,564 @@
+package vlc
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+	"unsafe"
+)
+
+// DefaultCredentialAttempts is the default number of times a credential
+// provider answers the same login dialog before dismissing it.
+const DefaultCredentialAttempts = 3
+
+// credentialRetryInterval is the interval after which the login attempts
+// counted for a login dialog are reset.
+const credentialRetryInterval = 30 * time.Second
+
+// Credentials contains the username and password used to access
+// authenticated network media.
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// CredentialRequest contains information about a login dialog which
+// requests credentials.
+type CredentialRequest struct {
+	// Title and text of the login dialog. The text usually contains the
+	// host or the realm (e.g. HTTP) the credentials are requested for.
+	Title string
+	Text  string
+
+	// Default username suggested by libVLC.
+	DefaultUsername string
+
+	// Number of the login attempt, starting at 1. Attempts greater than 1
+	// indicate that the previously provided credentials were rejected.
+	Attempt int
+}
+
+// Matches returns true if the specified host or realm is referenced by
+// the login dialog. The key must appear in the title or in the text of the
+// dialog as a whole host name or realm, ignoring case. For example, the key
+// `example.com` does not match dialogs which reference `notexample.com`,
+// `media.example.com` or `example.com.attacker.net`. An empty key matches
+// any login dialog.
+func (cr *CredentialRequest) Matches(key string) bool {
+	if key == "" {
+		return true
+	}
+
+	return containsHostFold(cr.Text, key) || containsHostFold(cr.Title, key)
+}
+
+// CredentialSource provides credentials for login dialogs. The available
+// sources are CredentialMap, CredentialFunc and Netrc.
+type CredentialSource interface {
+	// Credentials returns the credentials for the specified request. The
+	// method returns false if the source has no matching credentials.
+	Credentials(req *CredentialRequest) (*Credentials, bool)
+}
+
+// CredentialMap is an in-memory credential source, which maps hosts or
+// realms to credentials. The keys are matched against the login dialogs
+// using the CredentialRequest.Matches method. An empty key matches any
+// login dialog and is only used if no other key matches.
+type CredentialMap map[string]Credentials
+
+// Credentials returns the credentials for the specified request.
+func (cm CredentialMap) Credentials(req *CredentialRequest) (*Credentials, bool) {
+	// Prefer the longest matching key.
+	var match string
+	var found bool
+	for key := range cm {
+		if key != "" && req.Matches(key) && (!found || len(key) > len(match)) {
+			match, found = key, true
+		}
+	}
+	if !found {
+		if _, ok := cm[""]; !ok {
+			return nil, false
+		}
+	}
+
+	credentials := cm[match]
+	return &credentials, true
+}
+
+// CredentialFunc is a credential source backed by a user callback.
+type CredentialFunc func(req *CredentialRequest) (*Credentials, bool)
+
+// Credentials returns the credentials for the specified request.
+func (cf CredentialFunc) Credentials(req *CredentialRequest) (*Credentials, bool) {
+	if cf == nil {
+		return nil, false
+	}
+
+	return cf(req)
+}
+
+// Netrc is a credential source backed by a netrc file, which contains
+// machine entries with their logins and passwords. The machine names are
+// matched against the login dialogs using the CredentialRequest.Matches
+// method. The default entry, if present, matches any login dialog.
+type Netrc struct {
+	machines []*netrcMachine
+	fallback *Credentials
+}
+
+type netrcMachine struct {
+	name        string
+	credentials Credentials
+}
+
+// LoadNetrc parses the netrc file located at the specified path.
+func LoadNetrc(path string) (*Netrc, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseNetrc(f)
+}
+
+// ParseNetrc parses the netrc data read from the specified reader.
+// Macro definitions (macdef) are skipped.
+func ParseNetrc(r io.Reader) (*Netrc, error) {
+	netrc := &Netrc{}
+
+	var current *Credentials
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		tokens := strings.Fields(line)
+		for i := 0; i < len(tokens); i++ {
+			value := func() (string, error) {
+				if i+1 >= len(tokens) {
+					return "", fmt.Errorf("%w: netrc: missing value for %q", ErrInvalidCredentials, tokens[i])
+				}
+				i++
+				return tokens[i], nil
+			}
+
+			switch tokens[i] {
+			case "machine":
+				name, err := value()
+				if err != nil {
+					return nil, err
+				}
+				machine := &netrcMachine{name: name}
+				netrc.machines = append(netrc.machines, machine)
+				current = &machine.credentials
+			case "default":
+				netrc.fallback = &Credentials{}
+				current = netrc.fallback
+			case "login", "password", "account":
+				key := tokens[i]
+				v, err := value()
+				if err != nil {
+					return nil, err
+				}
+				if current == nil {
+					return nil, fmt.Errorf("%w: netrc: %q outside of machine entry", ErrInvalidCredentials, key)
+				}
+
+				switch key {
+				case "login":
+					current.Username = v
+				case "password":
+					current.Password = v
+				}
+			case "macdef":
+				// Skip macro definition, which ends with an empty line.
+				for scanner.Scan() && strings.TrimSpace(scanner.Text()) != "" {
+				}
+				i = len(tokens)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return netrc, nil
+}
+
+// Credentials returns the credentials for the specified request.
+func (n *Netrc) Credentials(req *CredentialRequest) (*Credentials, bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	for _, machine := range n.machines {
+		if req.Matches(machine.name) {
+			credentials := machine.credentials
+			return &credentials, true
+		}
+	}
+	if n.fallback != nil {
+		credentials := *n.fallback
+		return &credentials, true
+	}
+
+	return nil, false
+}
+
+// CredentialProvider is a dialog handler which answers login dialogs
+// automatically, using credentials obtained from the configured sources.
+// The sources are queried in order, until one of them returns credentials.
+// In order to prevent rejected credentials from being submitted
+// indefinitely, each login dialog is answered at most MaxAttempts times in
+// a row, after which it is dismissed. Register the provider using the
+// SetDialogHandler function or the Instance.SetDialogHandler method.
+//
+//	NOTE: libVLC only reports the title and the text of login dialogs.
+//	Depending on the protocol, the text contains either the host (e.g.
+//	SMB, FTP) or the realm (e.g. HTTP) the credentials are requested for.
+type CredentialProvider struct {
+	// Credential sources, in order of precedence.
+	Sources []CredentialSource
+
+	// Maximum number of times the same login dialog is answered. If 0,
+	// DefaultCredentialAttempts is used.
+	MaxAttempts int
+
+	// Optional dialog handler, which receives the login dialogs for which
+	// no credentials are found, as well as the other dialogs. If nil, the
+	// login and question dialogs are dismissed.
+	Handler DialogHandler
+
+	attempts loginAttempts
+}
+
+// NewCredentialProvider returns a new credential provider which uses the
+// specified credential sources.
+func NewCredentialProvider(sources ...CredentialSource) *CredentialProvider {
+	return &CredentialProvider{Sources: sources}
+}
+
+// Reset resets the login attempts counted by the provider.
+func (cp *CredentialProvider) Reset() {
+	cp.attempts.reset()
+}
+
+// DisplayError forwards the error to the handler of the provider.
+func (cp *CredentialProvider) DisplayError(title, text string) {
+	if cp.Handler != nil {
+		cp.Handler.DisplayError(title, text)
+	}
+}
+
+// DisplayLogin answers the login dialog using the credentials obtained
+// from the sources of the provider.
+func (cp *CredentialProvider) DisplayLogin(dialog *Dialog, defaultUsername string, askStore bool) {
+	req := &CredentialRequest{
+		Title:           dialog.Title,
+		Text:            dialog.Text,
+		DefaultUsername: defaultUsername,
+		Attempt:         cp.attempts.add(dialog.Title + "\x00" + dialog.Text),
+	}
+
+	maxAttempts := cp.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultCredentialAttempts
+	}
+	if req.Attempt > maxAttempts {
+		dialog.Dismiss()
+		return
+	}
+
+	for _, source := range cp.Sources {
+		if source == nil {
+			continue
+		}
+
+		if credentials, ok := source.Credentials(req); ok && credentials != nil {
+			dialog.PostLogin(credentials.Username, credentials.Password, false)
+			return
+		}
+	}
+
+	if cp.Handler != nil {
+		cp.Handler.DisplayLogin(dialog, defaultUsername, askStore)
+		return
+	}
+	dialog.Dismiss()
+}
+
+// DisplayQuestion forwards the question dialog to the handler of the
+// provider. If the provider has no handler, the dialog is dismissed.
+func (cp *CredentialProvider) DisplayQuestion(dialog *Dialog, questionType DialogQuestionType, cancel, action1, action2 string) {
+	if cp.Handler != nil {
+		cp.Handler.DisplayQuestion(dialog, questionType, cancel, action1, action2)
+		return
+	}
+	dialog.Dismiss()
+}
+
+// DisplayProgress forwards the progress dialog to the handler of the
+// provider.
+func (cp *CredentialProvider) DisplayProgress(dialog *Dialog, indeterminate bool, position float32, cancel string) {
+	if cp.Handler != nil {
+		cp.Handler.DisplayProgress(dialog, indeterminate, position, cancel)
+	}
+}
+
+// UpdateProgress forwards the progress update to the handler of the
+// provider.
+func (cp *CredentialProvider) UpdateProgress(dialog *Dialog, position float32, text string) {
+	if cp.Handler != nil {
+		cp.Handler.UpdateProgress(dialog, position, text)
+	}
+}
+
+// CancelDialog forwards the cancellation to the handler of the provider.
+// If the provider has no handler, the dialog is dismissed.
+func (cp *CredentialProvider) CancelDialog(dialog *Dialog) {
+	if cp.Handler != nil {
+		cp.Handler.CancelDialog(dialog)
+		return
+	}
+	dialog.Dismiss()
+}
+
+// loginAttempts counts the consecutive attempts of answering the same
+// login dialog.
+type loginAttempts struct {
+	sync.Mutex
+	attempts map[string]*credentialAttempts
+}
+
+type credentialAttempts struct {
+	count int
+	last  time.Time
+}
+
+// add counts a new attempt of answering the login dialog identified by
+// the specified key and returns the number of the attempt.
+func (la *loginAttempts) add(key string) int {
+	la.Lock()
+	defer la.Unlock()
+
+	if la.attempts == nil {
+		la.attempts = map[string]*credentialAttempts{}
+	}
+
+	now := time.Now()
+	attempts, ok := la.attempts[key]
+	if !ok || now.Sub(attempts.last) > credentialRetryInterval {
+		attempts = &credentialAttempts{}
+		la.attempts[key] = attempts
+	}
+	attempts.count++
+	attempts.last = now
+
+	return attempts.count
+}
+
+func (la *loginAttempts) reset() {
+	la.Lock()
+	la.attempts = nil
+	la.Unlock()
+}
+
+// containsHostFold returns true if the specified string contains the
+// provided host or realm, ignoring case. The occurrences of the key must
+// not be preceded or followed by characters which are part of host names.
+// A trailing dot (e.g. the end of a sentence) is allowed.
+func containsHostFold(s, key string) bool {
+	s, key = strings.ToLower(s), strings.ToLower(key)
+
+	for offset := 0; offset < len(s); {
+		idx := strings.Index(s[offset:], key)
+		if idx < 0 {
+			return false
+		}
+		start, end := offset+idx, offset+idx+len(key)
+
+		if start == 0 || !isHostByte(s[start-1]) {
+			if end < len(s) && s[end] == '.' {
+				end++
+			}
+			if end >= len(s) || !isHostByte(s[end]) {
+				return true
+			}
+		}
+		offset = start + 1
+	}
+
+	return false
+}
+
+// isHostByte returns true if the specified byte can be part of a host name.
+// Non-ASCII bytes are considered part of internationalized host names.
+func isHostByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' ||
+		b == '-' || b == '_' || b == '.' || b >= utf8.RuneSelf
+}
+
+// credentialSchemes contains the URL schemes supported by the
+// NewMediaFromURLWithCredentials function. The access modules of the
+// schemes request credentials using login dialogs which contain the host
+// of the media.
+var credentialSchemes = map[string]bool{
+	"ftp":   true,
+	"ftps":  true,
+	"ftpes": true,
+	"sftp":  true,
+	"smb":   true,
+}
+
+// NewMediaFromURLWithCredentials creates a new media instance based on the
+// media located at the specified URL, which is accessed using the provided
+// credentials. Unlike credentials embedded in the URL, the credentials are
+// not part of the location or of the options of the media, so they are not
+// returned by the Media.Location method and are not logged by libVLC.
+// Credentials embedded in the URL are removed.
+// Supported URL schemes: ftp, ftps, ftpes, sftp, smb.
+//
+//	NOTE: The credentials are used to answer the login dialogs of the
+//	instance which reference the host of the media, until the media is
+//	released. While media credentials are registered, login dialogs are
+//	handled by the instance even if no dialog handler is set. The other
+//	dialogs, and the login dialogs which are not answered using media
+//	credentials, are passed to the dialog handler set using the
+//	SetDialogHandler function, if any. Rejected credentials are submitted
+//	at most DefaultCredentialAttempts times in a row.
+func NewMediaFromURLWithCredentials(rawURL string, credentials Credentials) (*Media, error) {
+	return inst.NewMediaFromURLWithCredentials(rawURL, credentials)
+}
+
+// NewMediaFromURLWithCredentials creates a new media instance, which uses
+// the instance, based on the media located at the specified URL, which is
+// accessed using the provided credentials.
+// See the NewMediaFromURLWithCredentials function.
+func (i *Instance) NewMediaFromURLWithCredentials(rawURL string, credentials Credentials) (*Media, error) {
+	if err := i.assertInit(); err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if !credentialSchemes[strings.ToLower(u.Scheme)] {
+		return nil, fmt.Errorf("%w: unsupported URL scheme %q", ErrInvalidCredentials, u.Scheme)
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("%w: missing URL host", ErrInvalidCredentials)
+	}
+	u.User = nil
+
+	m, err := i.NewMediaFromURL(u.String())
+	if err != nil {
+		return nil, err
+	}
+
+	i.credentials.add(unsafe.Pointer(m.media), u.Hostname(), credentials)
+	i.updateDialogCallbacks()
+
+	return m, nil
+}
+
+// removeMediaCredentials removes the credentials of the specified media,
+// if it was created using the NewMediaFromURLWithCredentials method.
+func (i *Instance) removeMediaCredentials(media unsafe.Pointer) {
+	if i.credentials.remove(media) {
+		i.updateDialogCallbacks()
+	}
+}
+
+// mediaCredentialRegistry contains the credentials of the media created
+// using the NewMediaFromURLWithCredentials method, along with the hosts
+// they are used for.
+type mediaCredentialRegistry struct {
+	sync.Mutex
+
+	entries  map[unsafe.Pointer]*mediaCredentials
+	attempts loginAttempts
+}
+
+type mediaCredentials struct {
+	host        string
+	credentials Credentials
+}
+
+func newMediaCredentialRegistry() *mediaCredentialRegistry {
+	return &mediaCredentialRegistry{
+		entries: map[unsafe.Pointer]*mediaCredentials{},
+	}
+}
+
+func (mcr *mediaCredentialRegistry) add(media unsafe.Pointer, host string, credentials Credentials) {
+	mcr.Lock()
+	mcr.entries[media] = &mediaCredentials{host: host, credentials: credentials}
+	mcr.Unlock()
+}
+
+func (mcr *mediaCredentialRegistry) remove(media unsafe.Pointer) bool {
+	mcr.Lock()
+	defer mcr.Unlock()
+
+	if _, ok := mcr.entries[media]; !ok {
+		return false
+	}
+	delete(mcr.entries, media)
+
+	if len(mcr.entries) == 0 {
+		mcr.attempts.reset()
+	}
+	return true
+}
+
+func (mcr *mediaCredentialRegistry) empty() bool {
+	mcr.Lock()
+	defer mcr.Unlock()
+
+	return len(mcr.entries) == 0
+}
+
+// Credentials returns the credentials of the media whose host is
+// referenced by the specified request.
+func (mcr *mediaCredentialRegistry) Credentials(req *CredentialRequest) (*Credentials, bool) {
+	mcr.Lock()
+	defer mcr.Unlock()
+
+	for _, entry := range mcr.entries {
+		if req.Matches(entry.host) {
+			credentials := entry.credentials
+			return &credentials, true
+		}
+	}
+
+	return nil, false
+}
+
+// answer answers the specified login dialog using media credentials, if
+// the dialog references the host of a media. The method returns false if
+// the dialog was not answered.
+func (mcr *mediaCredentialRegistry) answer(dialog *Dialog) bool {
+	req := &CredentialRequest{Title: dialog.Title, Text: dialog.Text}
+
+	credentials, ok := mcr.Credentials(req)
+	if !ok {
+		return false
+	}
+	if mcr.attempts.add(dialog.Title+"\x00"+dialog.Text) > DefaultCredentialAttempts {
+		return false
+	}
+
+	dialog.PostLogin(credentials.Username, credentials.Password, false)
+	return true
+}
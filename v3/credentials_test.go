@@ -0,0 +1,193 @@
+package vlc
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCredentialRequestMatches(t *testing.T) {
+	tests := []struct {
+		name  string
+		title string
+		text  string
+		key   string
+		want  bool
+	}{
+		{name: "empty key", text: "Please enter a login for example.com", want: true},
+		{name: "exact host", text: "Please enter a login for example.com", key: "example.com", want: true},
+		{name: "case-insensitive", text: "Please enter a login for Example.COM", key: "EXAMPLE.com", want: true},
+		{name: "end of sentence", text: "Please enter a login for example.com.", key: "example.com", want: true},
+		{name: "host with port", text: "Please enter a login for example.com:2121", key: "example.com", want: true},
+		{name: "quoted realm", text: `Please enter a login for realm "Media Server".`, key: "media server", want: true},
+		{name: "title", title: "example.com authentication", text: "Please enter a login", key: "example.com", want: true},
+		{name: "IDN host", text: "Please enter a login for bücher.example", key: "Bücher.example", want: true},
+		{name: "lookalike prefix", text: "Please enter a login for notexample.com", key: "example.com"},
+		{name: "lookalike suffix", text: "Please enter a login for example.com.attacker.net", key: "example.com"},
+		{name: "lookalike hyphen", text: "Please enter a login for example.com-attacker.net", key: "example.com"},
+		{name: "subdomain", text: "Please enter a login for media.example.com", key: "example.com"},
+		{name: "lookalike IDN", text: "Please enter a login for éexample.com", key: "example.com"},
+		{name: "partial realm", text: `Please enter a login for realm "Media Servers".`, key: "media server"},
+		{name: "later occurrence", text: "notexample.com redirected to example.com", key: "example.com", want: true},
+		{name: "missing host", text: "Please enter a login", key: "example.com"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := &CredentialRequest{Title: test.title, Text: test.text}
+			if got := req.Matches(test.key); got != test.want {
+				t.Errorf("Matches(%q): got %t, want %t", test.key, got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseNetrc(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		text    string
+		want    *Credentials
+		invalid bool
+	}{
+		{
+			name: "single machine",
+			data: "machine example.com login alice password secret",
+			text: "Please enter a login for example.com",
+			want: &Credentials{Username: "alice", Password: "secret"},
+		},
+		{
+			name: "multiline entries",
+			data: "machine example.com\n\tlogin alice\n\tpassword secret\n\nmachine example.org\n\tlogin bob\n\tpassword hunter2\n",
+			text: "Please enter a login for example.org",
+			want: &Credentials{Username: "bob", Password: "hunter2"},
+		},
+		{
+			name: "comments and account",
+			data: "# credentials\nmachine example.com login alice account main password secret\n",
+			text: "Please enter a login for example.com",
+			want: &Credentials{Username: "alice", Password: "secret"},
+		},
+		{
+			name: "default entry",
+			data: "machine example.com login alice password secret\ndefault login anonymous password guest\n",
+			text: "Please enter a login for example.org",
+			want: &Credentials{Username: "anonymous", Password: "guest"},
+		},
+		{
+			name: "macro definition",
+			data: "macdef init\ncd /pub\nmachine fake.com login mallory password evil\n\nmachine example.com login alice password secret\n",
+			text: "Please enter a login for example.com",
+			want: &Credentials{Username: "alice", Password: "secret"},
+		},
+		{
+			name: "lookalike host",
+			data: "machine example.com login alice password secret",
+			text: "Please enter a login for example.com.attacker.net",
+		},
+		{
+			name: "no match",
+			data: "machine example.com login alice password secret",
+			text: "Please enter a login for example.org",
+		},
+		{
+			name:    "missing machine name",
+			data:    "machine",
+			invalid: true,
+		},
+		{
+			name:    "missing password",
+			data:    "machine example.com login alice password",
+			invalid: true,
+		},
+		{
+			name:    "login outside of machine entry",
+			data:    "login alice password secret",
+			invalid: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			netrc, err := ParseNetrc(strings.NewReader(test.data))
+			if test.invalid {
+				if !errors.Is(err, ErrInvalidCredentials) {
+					t.Fatalf("expected ErrInvalidCredentials, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, ok := netrc.Credentials(&CredentialRequest{Text: test.text})
+			checkCredentials(t, got, ok, test.want)
+		})
+	}
+}
+
+func TestCredentialMap(t *testing.T) {
+	credentials := CredentialMap{
+		"example.com":       {Username: "alice", Password: "secret"},
+		"media.example.com": {Username: "bob", Password: "hunter2"},
+	}
+	fallback := CredentialMap{
+		"example.com": {Username: "alice", Password: "secret"},
+		"":            {Username: "anonymous", Password: "guest"},
+	}
+
+	tests := []struct {
+		name        string
+		credentials CredentialMap
+		text        string
+		want        *Credentials
+	}{
+		{
+			name:        "host",
+			credentials: credentials,
+			text:        "Please enter a login for example.com",
+			want:        &Credentials{Username: "alice", Password: "secret"},
+		},
+		{
+			name:        "subdomain",
+			credentials: credentials,
+			text:        "Please enter a login for media.example.com",
+			want:        &Credentials{Username: "bob", Password: "hunter2"},
+		},
+		{
+			name:        "lookalike host",
+			credentials: credentials,
+			text:        "Please enter a login for notexample.com",
+		},
+		{
+			name:        "fallback",
+			credentials: fallback,
+			text:        "Please enter a login for example.com.attacker.net",
+			want:        &Credentials{Username: "anonymous", Password: "guest"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := test.credentials.Credentials(&CredentialRequest{Text: test.text})
+			checkCredentials(t, got, ok, test.want)
+		})
+	}
+}
+
+func checkCredentials(t *testing.T, got *Credentials, ok bool, want *Credentials) {
+	t.Helper()
+
+	if want == nil {
+		if ok {
+			t.Fatalf("expected no credentials, got %+v", got)
+		}
+		return
+	}
+	if !ok || got == nil {
+		t.Fatal("expected credentials, got none")
+	}
+	if *got != *want {
+		t.Errorf("got %+v, want %+v", *got, *want)
+	}
+}
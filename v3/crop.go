@@ -0,0 +1,239 @@
+package vlc
+
+// #cgo LDFLAGS: -lvlc
+// #include <vlc/vlc.h>
+// #include <stdlib.h>
+import "C"
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// CropGeometry represents the crop geometry of a video. The available crop
+// geometries are CropRatio, CropWindow and CropBorder.
+type CropGeometry interface {
+	// Validate returns an error if the crop geometry is not valid.
+	Validate() error
+
+	// String returns the libVLC representation of the crop geometry.
+	String() string
+}
+
+// CropRatio crops the video to the specified aspect ratio (e.g. 16:9).
+type CropRatio struct {
+	Num uint // Numerator of the aspect ratio.
+	Den uint // Denominator of the aspect ratio.
+}
+
+// Validate returns an error if the crop geometry is not valid.
+func (c *CropRatio) Validate() error {
+	if c.Num == 0 || c.Den == 0 {
+		return fmt.Errorf("%w: invalid ratio %d:%d", ErrInvalidCropGeometry, c.Num, c.Den)
+	}
+
+	return nil
+}
+
+// String returns the libVLC representation of the crop geometry (N:D).
+func (c *CropRatio) String() string {
+	return fmt.Sprintf("%d:%d", c.Num, c.Den)
+}
+
+// CropWindow crops the video to the window of the specified size, located
+// at the specified offset, in pixels.
+type CropWindow struct {
+	Width  uint // Width of the window.
+	Height uint // Height of the window.
+	X      uint // Horizontal offset of the window.
+	Y      uint // Vertical offset of the window.
+}
+
+// Validate returns an error if the crop geometry is not valid.
+func (c *CropWindow) Validate() error {
+	if c.Width == 0 || c.Height == 0 {
+		return fmt.Errorf("%w: invalid window size %dx%d", ErrInvalidCropGeometry, c.Width, c.Height)
+	}
+
+	return nil
+}
+
+// String returns the libVLC representation of the crop geometry (WxH+X+Y).
+func (c *CropWindow) String() string {
+	return fmt.Sprintf("%dx%d+%d+%d", c.Width, c.Height, c.X, c.Y)
+}
+
+// CropBorder crops the specified number of pixels from each border of
+// the video.
+type CropBorder struct {
+	Left   uint // Pixels cropped from the left border.
+	Top    uint // Pixels cropped from the top border.
+	Right  uint // Pixels cropped from the right border.
+	Bottom uint // Pixels cropped from the bottom border.
+}
+
+// Validate returns an error if the crop geometry is not valid.
+func (c *CropBorder) Validate() error {
+	return nil
+}
+
+// String returns the libVLC representation of the crop geometry (L+T+R+B).
+func (c *CropBorder) String() string {
+	return fmt.Sprintf("%d+%d+%d+%d", c.Left, c.Top, c.Right, c.Bottom)
+}
+
+// ParseCropGeometry parses the specified libVLC representation of a crop
+// geometry (N:D, WxH+X+Y or L+T+R+B). The offsets of crop windows are
+// optional (e.g. WxH).
+func ParseCropGeometry(geometry string) (CropGeometry, error) {
+	var crop CropGeometry
+	var values []uint
+	var ok bool
+
+	switch {
+	case strings.Contains(geometry, ":"):
+		if values, ok = parseCropValues(strings.Split(geometry, ":")); ok && len(values) == 2 {
+			crop = &CropRatio{Num: values[0], Den: values[1]}
+		}
+	case strings.Contains(geometry, "x"):
+		parts := strings.SplitN(geometry, "x", 2)
+		if len(parts) == 2 {
+			parts = append(parts[:1], strings.Split(parts[1], "+")...)
+		}
+		if values, ok = parseCropValues(parts); ok && len(values) >= 2 && len(values) <= 4 {
+			values = append(values, 0, 0)
+			crop = &CropWindow{Width: values[0], Height: values[1], X: values[2], Y: values[3]}
+		}
+	default:
+		if values, ok = parseCropValues(strings.Split(geometry, "+")); ok && len(values) == 4 {
+			crop = &CropBorder{Left: values[0], Top: values[1], Right: values[2], Bottom: values[3]}
+		}
+	}
+	if crop == nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidCropGeometry, geometry)
+	}
+	if err := crop.Validate(); err != nil {
+		return nil, err
+	}
+
+	return crop, nil
+}
+
+// parseCropValues parses the specified unsigned decimal crop values.
+func parseCropValues(parts []string) ([]uint, bool) {
+	values := make([]uint, 0, len(parts))
+	for _, part := range parts {
+		value, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return nil, false
+		}
+		values = append(values, uint(value))
+	}
+
+	return values, true
+}
+
+// Crop returns the crop geometry of the current video.
+//
+//	NOTE: The method returns nil if the video is not cropped.
+func (p *Player) Crop() (CropGeometry, error) {
+	if err := p.assertInit(); err != nil {
+		return nil, err
+	}
+
+	cGeometry := C.libvlc_video_get_crop_geometry(p.player)
+	if cGeometry == nil {
+		return nil, getError()
+	}
+	defer C.free(unsafe.Pointer(cGeometry))
+
+	geometry := C.GoString(cGeometry)
+	if geometry == "" {
+		return nil, nil
+	}
+
+	return ParseCropGeometry(geometry)
+}
+
+// SetCrop sets the crop geometry of the current video (e.g.
+// &vlc.CropRatio{Num: 16, Den: 9}). Pass in nil in order to disable
+// cropping.
+func (p *Player) SetCrop(crop CropGeometry) error {
+	if err := p.assertInit(); err != nil {
+		return err
+	}
+
+	var geometry string
+	if crop != nil {
+		if err := crop.Validate(); err != nil {
+			return err
+		}
+		geometry = crop.String()
+	}
+
+	var cGeometry *C.char
+	if geometry != "" {
+		cGeometry = C.CString(geometry)
+		defer C.free(unsafe.Pointer(cGeometry))
+	}
+
+	C.libvlc_video_set_crop_geometry(p.player, cGeometry)
+	return getError()
+}
+
+// ZoomTo displays a region of the current video, magnified by the specified
+// zoom factor. The center of the region is specified using coordinates
+// relative to the video dimensions, in the [0.0, 1.0] interval. The region
+// is cropped from the video and the scaling factor of the player is reset,
+// so that the region fills the available space. A zoom factor of 1
+// displays the whole video.
+//
+//	NOTE: The dimensions of the video must be available. Either play the
+//	media or call one of the media parsing methods first.
+func (p *Player) ZoomTo(centerX, centerY, zoom float64) error {
+	if err := p.assertInit(); err != nil {
+		return err
+	}
+	if zoom < 1 || centerX < 0 || centerX > 1 || centerY < 0 || centerY > 1 {
+		return ErrInvalidZoomRegion
+	}
+
+	width, height, err := p.VideoDimensions()
+	if err != nil {
+		return err
+	}
+	if width == 0 || height == 0 {
+		return ErrMissingMediaDimensions
+	}
+
+	// Compute the region, keeping it inside the video.
+	region := &CropWindow{
+		Width:  uint(math.Max(1, math.Round(float64(width)/zoom))),
+		Height: uint(math.Max(1, math.Round(float64(height)/zoom))),
+	}
+	region.X = zoomOffset(centerX, width, region.Width)
+	region.Y = zoomOffset(centerY, height, region.Height)
+
+	if err := p.SetCrop(region); err != nil {
+		return err
+	}
+
+	return p.SetScale(0)
+}
+
+// ResetZoom displays the whole video, by disabling cropping and resetting
+// the scaling factor of the player.
+func (p *Player) ResetZoom() error {
+	if err := p.SetCrop(nil); err != nil {
+		return err
+	}
+
+	return p.SetScale(0)
+}
+
+func zoomOffset(center float64, size, regionSize uint) uint {
+	offset := math.Round(center*float64(size) - float64(regionSize)/2)
+	return uint(math.Max(0, math.Min(offset, float64(size-regionSize))))
+}
@@ -0,0 +1,76 @@
+package vlc
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseCropGeometry(t *testing.T) {
+	tests := []struct {
+		geometry string
+		want     CropGeometry
+	}{
+		// Aspect ratios.
+		{geometry: "16:9", want: &CropRatio{Num: 16, Den: 9}},
+		{geometry: "4:3", want: &CropRatio{Num: 4, Den: 3}},
+		{geometry: "0:9"},
+		{geometry: "16:0"},
+		{geometry: "16:"},
+		{geometry: ":9"},
+		{geometry: "16:9:1"},
+		{geometry: "16:9abc"},
+		{geometry: "-16:9"},
+		{geometry: "+16:9"},
+		{geometry: " 16:9"},
+
+		// Windows.
+		{geometry: "640x480", want: &CropWindow{Width: 640, Height: 480}},
+		{geometry: "640x480+10", want: &CropWindow{Width: 640, Height: 480, X: 10}},
+		{geometry: "640x480+10+20", want: &CropWindow{Width: 640, Height: 480, X: 10, Y: 20}},
+		{geometry: "0x480"},
+		{geometry: "640x0+10+20"},
+		{geometry: "640x"},
+		{geometry: "x480"},
+		{geometry: "640x480+"},
+		{geometry: "640x480+10+20+30"},
+		{geometry: "640x480x2"},
+		{geometry: "640x480+10+20px"},
+		{geometry: "640x-480"},
+
+		// Borders.
+		{geometry: "10+20+30+40", want: &CropBorder{Left: 10, Top: 20, Right: 30, Bottom: 40}},
+		{geometry: "0+0+0+0", want: &CropBorder{}},
+		{geometry: "10+20+30"},
+		{geometry: "10+20+30+40+50"},
+		{geometry: "10+20+30+"},
+		{geometry: "10+20+30+4O"},
+		{geometry: "99999999999+0+0+0"},
+
+		// Other.
+		{geometry: ""},
+		{geometry: "abc"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.geometry, func(t *testing.T) {
+			got, err := ParseCropGeometry(test.geometry)
+			if test.want == nil {
+				if !errors.Is(err, ErrInvalidCropGeometry) {
+					t.Fatalf("expected ErrInvalidCropGeometry, got %v (%v)", err, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %#v, want %#v", got, test.want)
+			}
+			if parsed, err := ParseCropGeometry(got.String()); err != nil || !reflect.DeepEqual(parsed, got) {
+				t.Errorf("round trip of %q: got %#v (%v)", got.String(), parsed, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,434 @@
+package vlc
+
+/*
+#cgo LDFLAGS: -lvlc
+#include <vlc/vlc.h>
+#include <stdlib.h>
+
+extern void dialogErrorCB(void*, char*, char*);
+extern void dialogLoginCB(void*, libvlc_dialog_id*, char*, char*, char*, bool);
+extern void dialogQuestionCB(void*, libvlc_dialog_id*, char*, char*, int, char*, char*, char*);
+extern void dialogProgressCB(void*, libvlc_dialog_id*, char*, char*, bool, float, char*);
+extern void dialogCancelCB(void*, libvlc_dialog_id*);
+extern void dialogUpdateProgressCB(void*, libvlc_dialog_id*, float, char*);
+
+static inline void dialogError(void* data, const char* title, const char* text) {
+	dialogErrorCB(data, (char*)title, (char*)text);
+}
+
+static inline void dialogLogin(void* data, libvlc_dialog_id* id, const char* title, const char* text,
+	const char* defaultUsername, bool askStore) {
+	dialogLoginCB(data, id, (char*)title, (char*)text, (char*)defaultUsername, askStore);
+}
+
+static inline void dialogQuestion(void* data, libvlc_dialog_id* id, const char* title, const char* text,
+	libvlc_dialog_question_type type, const char* cancel, const char* action1, const char* action2) {
+	dialogQuestionCB(data, id, (char*)title, (char*)text, (int)type, (char*)cancel, (char*)action1, (char*)action2);
+}
+
+static inline void dialogProgress(void* data, libvlc_dialog_id* id, const char* title, const char* text,
+	bool indeterminate, float position, const char* cancel) {
+	dialogProgressCB(data, id, (char*)title, (char*)text, indeterminate, position, (char*)cancel);
+}
+
+static inline void dialogUpdateProgress(void* data, libvlc_dialog_id* id, float position, const char* text) {
+	dialogUpdateProgressCB(data, id, position, (char*)text);
+}
+
+static inline void dialogSetCallbacks(libvlc_instance_t* instance, void* data) {
+	static const libvlc_dialog_cbs cbs = {
+		dialogError,
+		dialogLogin,
+		dialogQuestion,
+		dialogProgress,
+		dialogCancelCB,
+		dialogUpdateProgress,
+	};
+
+	libvlc_dialog_set_callbacks(instance, data != NULL ? &cbs : NULL, data);
+}
+*/
+import "C"
+import (
+	"sync"
+	"unsafe"
+)
+
+// DialogType represents the type of a dialog.
+type DialogType uint
+
+// Dialog types.
+const (
+	// Login dialogs request credentials (e.g. for authenticated network
+	// media). Answer using the PostLogin method.
+	DialogLogin DialogType = iota
+
+	// Question dialogs request the user to choose between one or two
+	// actions. Answer using the PostAction method.
+	DialogQuestion
+
+	// Progress dialogs inform the user about the progress of an operation.
+	// Cancellable progress dialogs can be cancelled using the Dismiss method.
+	DialogProgress
+)
+
+// DialogQuestionType represents the severity of a question dialog.
+type DialogQuestionType uint
+
+// Dialog question types.
+const (
+	DialogQuestionNormal DialogQuestionType = iota
+	DialogQuestionWarning
+	DialogQuestionCritical
+)
+
+// Dialog represents a dialog displayed by libVLC, which is waiting for an
+// answer. Each dialog must be answered, using the PostLogin or PostAction
+// methods, or dismissed, using the Dismiss method, exactly once. The
+// dialog can be answered from any goroutine, either while handling it or
+// at a later time.
+type Dialog struct {
+	Type  DialogType // Type of the dialog.
+	Title string     // Title of the dialog.
+	Text  string     // Text of the dialog.
+
+	mu   sync.Mutex
+	id   *C.libvlc_dialog_id
+	inst *Instance
+}
+
+// PostLogin answers a login dialog with the specified credentials. If store
+// is true, the credentials are saved by libVLC, if the dialog allows it.
+func (d *Dialog) PostLogin(username, password string, store bool) error {
+	id, err := d.take()
+	if err != nil {
+		return err
+	}
+
+	cUsername, cPassword := C.CString(username), C.CString(password)
+	defer C.free(unsafe.Pointer(cUsername))
+	defer C.free(unsafe.Pointer(cPassword))
+
+	if C.libvlc_dialog_post_login(id, cUsername, cPassword, C.bool(store)) != 0 {
+		return errOrDefault(getError(), ErrDialogPost)
+	}
+
+	return nil
+}
+
+// PostAction answers a question dialog with the specified action. The
+// action must be either 1 or 2, corresponding to the first or the second
+// action of the question.
+func (d *Dialog) PostAction(action int) error {
+	if action != 1 && action != 2 {
+		return ErrInvalidDialogAction
+	}
+
+	id, err := d.take()
+	if err != nil {
+		return err
+	}
+
+	if C.libvlc_dialog_post_action(id, C.int(action)) != 0 {
+		return errOrDefault(getError(), ErrDialogPost)
+	}
+
+	return nil
+}
+
+// Dismiss dismisses the dialog. Dismissing a login or a question dialog
+// is equivalent to cancelling it, while dismissing a progress dialog
+// cancels the operation in progress, if the dialog is cancellable.
+// Dismissing a dialog which is no longer active (e.g. answered, or
+// cancelled by libVLC) has no effect.
+func (d *Dialog) Dismiss() error {
+	id, err := d.take()
+	if err != nil {
+		return nil
+	}
+
+	if C.libvlc_dialog_dismiss(id) != 0 {
+		return errOrDefault(getError(), ErrDialogPost)
+	}
+
+	return nil
+}
+
+// take invalidates the dialog and returns its libVLC identifier.
+func (d *Dialog) take() (*C.libvlc_dialog_id, error) {
+	if d == nil {
+		return nil, ErrDialogNotActive
+	}
+
+	d.mu.Lock()
+	id := d.id
+	d.id = nil
+	d.mu.Unlock()
+
+	if id == nil {
+		return nil, ErrDialogNotActive
+	}
+	d.inst.dialogs.remove(id)
+
+	return id, nil
+}
+
+// DialogHandler handles the dialogs displayed by libVLC (e.g. login prompts
+// for authenticated network media). The methods of the handler are called
+// from libVLC threads, so they should return quickly. The dialogs can be
+// answered from other goroutines, once the methods return.
+type DialogHandler interface {
+	// DisplayError displays an error message. Error dialogs do not
+	// require an answer.
+	DisplayError(title, text string)
+
+	// DisplayLogin displays a login dialog. The dialog is answered using
+	// the PostLogin method. If askStore is true, the user can choose to
+	// have the credentials saved.
+	DisplayLogin(dialog *Dialog, defaultUsername string, askStore bool)
+
+	// DisplayQuestion displays a question dialog. The dialog is answered
+	// using the PostAction method. The cancel label is always specified,
+	// while the labels of the actions are optional.
+	DisplayQuestion(dialog *Dialog, questionType DialogQuestionType, cancel, action1, action2 string)
+
+	// DisplayProgress displays a progress dialog, with the position in the
+	// [0.0, 1.0] interval, unless the progress is indeterminate. The dialog
+	// can be cancelled using the Dismiss method if the cancel label is
+	// not empty.
+	DisplayProgress(dialog *Dialog, indeterminate bool, position float32, cancel string)
+
+	// UpdateProgress updates the position and the text of a progress dialog.
+	UpdateProgress(dialog *Dialog, position float32, text string)
+
+	// CancelDialog signals that the dialog is no longer needed (e.g. the
+	// operation which requested it was stopped). The dialog is dismissed
+	// once the method returns, so it can no longer be answered.
+	CancelDialog(dialog *Dialog)
+}
+
+// SetDialogHandler sets the handler of the dialogs displayed by libVLC.
+// Pass in nil in order to remove the handler, in which case the dialogs
+// are no longer displayed (e.g. login prompts fail). Login dialogs which
+// reference the host of a media created using the
+// NewMediaFromURLWithCredentials function are answered before reaching
+// the handler.
+func SetDialogHandler(handler DialogHandler) error {
+	return inst.SetDialogHandler(handler)
+}
+
+// SetDialogHandler sets the handler of the dialogs displayed by the
+// instance. Pass in nil in order to remove the handler.
+func (i *Instance) SetDialogHandler(handler DialogHandler) error {
+	if err := i.assertInit(); err != nil {
+		return err
+	}
+
+	i.dialogMu.Lock()
+	defer i.dialogMu.Unlock()
+
+	// Replace previous handler.
+	i.unsetDialogHandler()
+	i.dialogHandler = handler
+	i.setDialogCallbacks()
+
+	return nil
+}
+
+// setDialogCallbacks registers the dialog callbacks of the instance, if a
+// dialog handler is set or if media credentials are registered.
+func (i *Instance) setDialogCallbacks() {
+	handler := i.dialogHandler
+	if handler == nil {
+		if i.credentials.empty() {
+			return
+		}
+
+		// Dismiss the dialogs which are not answered using media
+		// credentials.
+		handler = &CredentialProvider{}
+	}
+
+	i.dialogHandlerID = i.objects.add(handler)
+	C.dialogSetCallbacks(i.handle, unsafe.Pointer(i.dialogHandlerID))
+}
+
+// updateDialogCallbacks registers or removes the dialog callbacks of the
+// instance when media credentials are added or removed.
+func (i *Instance) updateDialogCallbacks() {
+	if err := i.assertInit(); err != nil {
+		return
+	}
+
+	i.dialogMu.Lock()
+	defer i.dialogMu.Unlock()
+
+	if i.dialogHandler != nil || (i.dialogHandlerID != nil) != i.credentials.empty() {
+		return
+	}
+
+	i.unsetDialogHandler()
+	i.setDialogCallbacks()
+}
+
+func (i *Instance) unsetDialogHandler() {
+	if i.dialogHandlerID == nil {
+		return
+	}
+	C.dialogSetCallbacks(i.handle, nil)
+
+	// Dismiss pending dialogs, which can no longer be answered.
+	for _, dialog := range i.dialogs.clear() {
+		dialog.Dismiss()
+	}
+
+	i.objects.decRefs(i.dialogHandlerID)
+	i.dialogHandlerID = nil
+}
+
+// dialogRegistry keeps track of the dialogs which are waiting for
+// an answer.
+type dialogRegistry struct {
+	sync.Mutex
+	dialogs map[*C.libvlc_dialog_id]*Dialog
+}
+
+func newDialogRegistry() *dialogRegistry {
+	return &dialogRegistry{
+		dialogs: map[*C.libvlc_dialog_id]*Dialog{},
+	}
+}
+
+func (dr *dialogRegistry) add(inst *Instance, id *C.libvlc_dialog_id, dialogType DialogType, title, text *C.char) *Dialog {
+	dialog := &Dialog{
+		Type:  dialogType,
+		Title: C.GoString(title),
+		Text:  C.GoString(text),
+		id:    id,
+		inst:  inst,
+	}
+
+	dr.Lock()
+	dr.dialogs[id] = dialog
+	dr.Unlock()
+
+	return dialog
+}
+
+func (dr *dialogRegistry) get(id *C.libvlc_dialog_id) (*Dialog, bool) {
+	dr.Lock()
+	defer dr.Unlock()
+
+	dialog, ok := dr.dialogs[id]
+	return dialog, ok
+}
+
+func (dr *dialogRegistry) remove(id *C.libvlc_dialog_id) {
+	dr.Lock()
+	delete(dr.dialogs, id)
+	dr.Unlock()
+}
+
+func (dr *dialogRegistry) clear() []*Dialog {
+	dr.Lock()
+	defer dr.Unlock()
+
+	dialogs := make([]*Dialog, 0, len(dr.dialogs))
+	for id, dialog := range dr.dialogs {
+		dialogs = append(dialogs, dialog)
+		delete(dr.dialogs, id)
+	}
+
+	return dialogs
+}
+
+func getDialogHandler(data unsafe.Pointer) (*Instance, DialogHandler, bool) {
+	inst, obj, ok := instances.object(data)
+	if !ok {
+		return nil, nil, false
+	}
+
+	handler, ok := obj.(DialogHandler)
+	return inst, handler, ok && handler != nil
+}
+
+//export dialogErrorCB
+func dialogErrorCB(data unsafe.Pointer, title, text *C.char) {
+	if _, handler, ok := getDialogHandler(data); ok {
+		handler.DisplayError(C.GoString(title), C.GoString(text))
+	}
+}
+
+//export dialogLoginCB
+func dialogLoginCB(data unsafe.Pointer, id *C.libvlc_dialog_id, title, text, defaultUsername *C.char, askStore C.bool) {
+	inst, handler, ok := getDialogHandler(data)
+	if !ok {
+		C.libvlc_dialog_dismiss(id)
+		return
+	}
+
+	dialog := inst.dialogs.add(inst, id, DialogLogin, title, text)
+	if inst.credentials.answer(dialog) {
+		return
+	}
+	handler.DisplayLogin(dialog, C.GoString(defaultUsername), bool(askStore))
+}
+
+//export dialogQuestionCB
+func dialogQuestionCB(data unsafe.Pointer, id *C.libvlc_dialog_id, title, text *C.char,
+	questionType C.int, cancel, action1, action2 *C.char) {
+	inst, handler, ok := getDialogHandler(data)
+	if !ok {
+		C.libvlc_dialog_dismiss(id)
+		return
+	}
+
+	dialog := inst.dialogs.add(inst, id, DialogQuestion, title, text)
+	handler.DisplayQuestion(dialog, DialogQuestionType(questionType),
+		C.GoString(cancel), C.GoString(action1), C.GoString(action2))
+}
+
+//export dialogProgressCB
+func dialogProgressCB(data unsafe.Pointer, id *C.libvlc_dialog_id, title, text *C.char,
+	indeterminate C.bool, position C.float, cancel *C.char) {
+	inst, handler, ok := getDialogHandler(data)
+	if !ok {
+		return
+	}
+
+	dialog := inst.dialogs.add(inst, id, DialogProgress, title, text)
+	handler.DisplayProgress(dialog, bool(indeterminate), float32(position), C.GoString(cancel))
+}
+
+//export dialogCancelCB
+func dialogCancelCB(data unsafe.Pointer, id *C.libvlc_dialog_id) {
+	inst, handler, ok := getDialogHandler(data)
+	if !ok {
+		C.libvlc_dialog_dismiss(id)
+		return
+	}
+
+	dialog, ok := inst.dialogs.get(id)
+	if !ok {
+		C.libvlc_dialog_dismiss(id)
+		return
+	}
+
+	// The libVLC thread waits for the dialog to be dismissed, so it is
+	// dismissed once the handler is notified, unless the handler already
+	// answered it.
+	handler.CancelDialog(dialog)
+	dialog.Dismiss()
+}
+
+//export dialogUpdateProgressCB
+func dialogUpdateProgressCB(data unsafe.Pointer, id *C.libvlc_dialog_id, position C.float, text *C.char) {
+	inst, handler, ok := getDialogHandler(data)
+	if !ok {
+		return
+	}
+
+	if dialog, ok := inst.dialogs.get(id); ok {
+		handler.UpdateProgress(dialog, float32(position), C.GoString(text))
+	}
+}
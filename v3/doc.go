@@ -206,5 +206,125 @@ Handling multiple events example
 	}
 
 	<-quit
+
+Event subscription example
+
+	// Create a new player.
+	player, err := vlc.NewPlayer()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer func() {
+		player.Stop()
+		player.Release()
+	}()
+
+	media, err := player.LoadMediaFromURL("http://stream-uk1.radioparadise.com/mp3-32")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer media.Release()
+
+	// Retrieve player event manager.
+	manager, err := player.EventManager()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Subscribe to player events. The events are detached when
+	// the context is cancelled.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := manager.Subscribe(ctx,
+		vlc.MediaPlayerTimeChanged,
+		vlc.MediaPlayerEndReached,
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Start playing the media.
+	if err = player.Play(); err != nil {
+		log.Fatal(err)
+	}
+
+	for info := range events {
+		switch payload := info.Payload.(type) {
+		case *vlc.TimePayload:
+			log.Printf("Playback time: %s\n", payload.Time)
+		default:
+			if info.Event == vlc.MediaPlayerEndReached {
+				cancel()
+			}
+		}
+	}
+
+Multiple instances example
+
+	// Create an instance which does not output audio.
+	silent, err := vlc.NewInstance("--quiet", "--aout=dummy")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer silent.Release()
+
+	// Create an instance with a larger network cache.
+	buffered, err := vlc.NewInstance("--quiet", "--network-caching=3000")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer buffered.Release()
+
+	// Create a player for each instance.
+	for _, instance := range []*vlc.Instance{silent, buffered} {
+		player, err := instance.NewPlayer()
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer func() {
+			player.Stop()
+			player.Release()
+		}()
+
+		if _, err = player.LoadMediaFromURL("http://stream-uk1.radioparadise.com/mp3-32"); err != nil {
+			log.Fatal(err)
+		}
+		if err = player.Play(); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+Thumbnailer example
+
+	// Create a thumbnailer which produces 320 pixels wide images.
+	thumbnailer, err := vlc.NewThumbnailer(320, 0, 5*time.Second)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer thumbnailer.Release()
+
+	media, err := vlc.NewMediaFromPath("test.mp4")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer media.Release()
+
+	// Take images at 10%, 50% and 90% of the media length.
+	images, err := thumbnailer.AtPositions(media, 0.1, 0.5, 0.9)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for i, img := range images {
+		f, err := os.Create(fmt.Sprintf("thumbnail-%d.png", i))
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err = png.Encode(f, img); err != nil {
+			log.Fatal(err)
+		}
+		f.Close()
+	}
 */
 package vlc
@@ -50,6 +50,8 @@ var (
 	ErrMediaMetaSave           = errors.New("could not save media metadata")
 	ErrMediaParse              = errors.New("could not parse media")
 	ErrMediaNotParsed          = errors.New("media is not parsed")
+	ErrMediaNotLocal           = errors.New("media is not a local file")
+	ErrInvalidMediaOptions     = errors.New("invalid media options")
 )
 
 // Media track errors.
@@ -57,6 +59,33 @@ var (
 	ErrMediaTrackNotInitialized = errors.New("media track is not initialized")
 	ErrMediaTrackNotFound       = errors.New("could not find media track")
 	ErrInvalidMediaTrack        = errors.New("invalid media track")
+	ErrInvalidTrackPolicy       = errors.New("invalid track policy")
+)
+
+// Dialog errors.
+var (
+	ErrDialogNotActive     = errors.New("dialog is no longer active")
+	ErrDialogPost          = errors.New("could not post dialog answer")
+	ErrInvalidDialogAction = errors.New("invalid dialog action")
+	ErrInvalidCredentials  = errors.New("invalid credentials")
+)
+
+// VLM errors.
+var (
+	ErrVLMNotInitialized = errors.New("vlm is not initialized")
+	ErrVLMCommand        = errors.New("could not execute vlm command")
+)
+
+// Teletext errors.
+var (
+	ErrInvalidTeletextPage = errors.New("invalid teletext page")
+	ErrInvalidTeletextKey  = errors.New("invalid teletext key")
+)
+
+// Media slave errors.
+var (
+	ErrInvalidMediaSlave = errors.New("invalid media slave")
+	ErrMediaSlaveAdd     = errors.New("could not add media slave")
 )
 
 // Event manager errors.
@@ -76,7 +105,14 @@ var (
 	ErrStereoModeSet                = errors.New("could not set stereo mode")
 	ErrVideoViewpointSet            = errors.New("could not set video viewpoint")
 	ErrVideoSnapshot                = errors.New("could not take video snapshot")
+	ErrInvalidSnapshotFormat        = errors.New("invalid snapshot format")
 	ErrCursorPositionMissing        = errors.New("could not get cursor position")
+	ErrInvalidVideoSink             = errors.New("invalid video sink")
+	ErrVideoFormatUnsupported       = errors.New("unsupported video format")
+	ErrInvalidAudioSink             = errors.New("invalid audio sink")
+	ErrAudioFormatUnsupported       = errors.New("unsupported audio format")
+	ErrInvalidCropGeometry          = errors.New("invalid crop geometry")
+	ErrInvalidZoomRegion            = errors.New("invalid zoom region")
 )
 
 // Renderer discoverer errors.
@@ -102,3 +138,35 @@ var (
 	ErrEqualizerNotInitialized = errors.New("equalizer not initialized")
 	ErrEqualizerAmpValueSet    = errors.New("could not set equalizer amplification value")
 )
+
+// Thumbnailer errors.
+var (
+	ErrThumbnailerNotInitialized = errors.New("thumbnailer not initialized")
+	ErrThumbnailTimeout          = errors.New("timed out waiting for thumbnail")
+)
+
+// Stream output errors.
+var (
+	ErrInvalidStreamOutput = errors.New("invalid stream output")
+)
+
+// Recording errors.
+var (
+	ErrInvalidRecordingContainer = errors.New("invalid recording container")
+	ErrRecordingActive           = errors.New("recording is already active")
+	ErrRecordingNotActive        = errors.New("recording is not active")
+	ErrRecordingFailed           = errors.New("recording failed")
+)
+
+// Export errors.
+var (
+	ErrExportFailed           = errors.New("could not export media")
+	ErrInvalidTranscodePreset = errors.New("invalid transcode preset")
+)
+
+// Stream server errors.
+var (
+	ErrStreamServerNotInitialized = errors.New("stream server is not initialized")
+	ErrStreamServerStarted        = errors.New("stream server is already started")
+	ErrStreamServerStart          = errors.New("could not start stream server")
+)
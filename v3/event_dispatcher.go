@@ -0,0 +1,179 @@
+package vlc
+
+import (
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// EventDispatchMode defines the ways in which event callbacks can be executed.
+type EventDispatchMode uint32
+
+// Event dispatch modes.
+const (
+	// The callbacks are executed synchronously, on the internal libVLC thread
+	// which triggered the event. Calling methods which wait for the libVLC
+	// event threads to finish (e.g. Player.Stop, Player.SetMedia, Release)
+	// from the callbacks results in undefined behavior.
+	EventDispatchSync EventDispatchMode = iota
+
+	// The callbacks are queued and executed on a separate goroutine. The
+	// events of an event manager are delivered in the order in which they
+	// were triggered. The callbacks can safely call any method, including
+	// Player.Stop, Player.SetMedia and Release.
+	EventDispatchAsync
+)
+
+// Validate checks if the event dispatch mode is valid.
+func (mode EventDispatchMode) Validate() error {
+	if mode > EventDispatchAsync {
+		return ErrInvalid
+	}
+
+	return nil
+}
+
+var eventDispatchMode uint32
+
+// SetEventDispatchMode sets the default mode used to execute event callbacks.
+// The mode applies to the event managers retrieved after the call. The mode
+// of an individual event manager can be changed using the
+// EventManager.SetDispatchMode method. Default: EventDispatchSync.
+func SetEventDispatchMode(mode EventDispatchMode) error {
+	if err := mode.Validate(); err != nil {
+		return err
+	}
+
+	atomic.StoreUint32(&eventDispatchMode, uint32(mode))
+	return nil
+}
+
+func defaultEventDispatchMode() EventDispatchMode {
+	return EventDispatchMode(atomic.LoadUint32(&eventDispatchMode))
+}
+
+type eventDispatcher struct {
+	mu    sync.Mutex
+	cond  *sync.Cond
+	queue []func()
+	refs  uint
+	done  bool
+}
+
+func newEventDispatcher() *eventDispatcher {
+	ed := &eventDispatcher{}
+	ed.cond = sync.NewCond(&ed.mu)
+
+	go ed.run()
+	return ed
+}
+
+// enqueue queues the specified function. Returns false if the dispatcher
+// is stopped, in which case the function is not executed.
+func (ed *eventDispatcher) enqueue(fn func()) bool {
+	ed.mu.Lock()
+	defer ed.mu.Unlock()
+
+	if ed.done {
+		return false
+	}
+	ed.queue = append(ed.queue, fn)
+	ed.cond.Signal()
+
+	return true
+}
+
+// stop stops the dispatcher once the queued functions are executed. The
+// queued functions are responsible for skipping the callbacks of the events
+// which were detached in the meantime.
+func (ed *eventDispatcher) stop() {
+	ed.mu.Lock()
+	ed.done = true
+	ed.cond.Signal()
+	ed.mu.Unlock()
+}
+
+func (ed *eventDispatcher) run() {
+	for {
+		ed.mu.Lock()
+		for len(ed.queue) == 0 && !ed.done {
+			ed.cond.Wait()
+		}
+		if len(ed.queue) == 0 {
+			ed.mu.Unlock()
+			return
+		}
+
+		fn := ed.queue[0]
+		ed.queue[0] = nil
+		ed.queue = ed.queue[1:]
+		ed.mu.Unlock()
+
+		fn()
+	}
+}
+
+// eventDispatcherRegistry keeps track of the dispatchers used to execute
+// event callbacks asynchronously. Each libVLC event manager has its own
+// dispatcher, in order to preserve the order of its events.
+type eventDispatcherRegistry struct {
+	sync.Mutex
+
+	dispatchers map[unsafe.Pointer]*eventDispatcher
+}
+
+func newEventDispatcherRegistry() *eventDispatcherRegistry {
+	return &eventDispatcherRegistry{
+		dispatchers: map[unsafe.Pointer]*eventDispatcher{},
+	}
+}
+
+func (edr *eventDispatcherRegistry) acquire(manager unsafe.Pointer) *eventDispatcher {
+	edr.Lock()
+	defer edr.Unlock()
+
+	ed, ok := edr.dispatchers[manager]
+	if !ok {
+		ed = newEventDispatcher()
+		edr.dispatchers[manager] = ed
+	}
+	ed.refs++
+
+	return ed
+}
+
+func (edr *eventDispatcherRegistry) release(manager unsafe.Pointer) {
+	edr.Lock()
+	defer edr.Unlock()
+
+	ed, ok := edr.dispatchers[manager]
+	if !ok {
+		return
+	}
+
+	if ed.refs--; ed.refs == 0 {
+		// The queued callbacks belong to detached events, so they are skipped.
+		delete(edr.dispatchers, manager)
+		ed.stop()
+	}
+}
+
+func (edr *eventDispatcherRegistry) stop(manager unsafe.Pointer) {
+	edr.Lock()
+	defer edr.Unlock()
+
+	if ed, ok := edr.dispatchers[manager]; ok {
+		delete(edr.dispatchers, manager)
+		ed.stop()
+	}
+}
+
+func (edr *eventDispatcherRegistry) stopAll() {
+	edr.Lock()
+	defer edr.Unlock()
+
+	for manager, ed := range edr.dispatchers {
+		delete(edr.dispatchers, manager)
+		ed.stop()
+	}
+}
@@ -17,38 +17,119 @@ static inline int eventDetach(libvlc_event_manager_t* em, libvlc_event_type_t et
 import "C"
 
 import (
+	"sync"
 	"unsafe"
 )
 
 // EventManager wraps a libvlc event manager.
 type EventManager struct {
 	manager *C.libvlc_event_manager_t
+	inst    *Instance
+
+	mu           sync.Mutex
+	dispatchMode EventDispatchMode
 }
 
 // newEventManager returns a new event manager instance.
-func newEventManager(manager *C.libvlc_event_manager_t) *EventManager {
+func newEventManager(inst *Instance, manager *C.libvlc_event_manager_t) *EventManager {
 	return &EventManager{
-		manager: manager,
+		manager:      manager,
+		inst:         inst,
+		dispatchMode: defaultEventDispatchMode(),
 	}
 }
 
+// DispatchMode returns the mode used to execute the callbacks
+// registered through the event manager.
+func (em *EventManager) DispatchMode() EventDispatchMode {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+
+	return em.dispatchMode
+}
+
+// SetDispatchMode sets the mode used to execute the callbacks registered
+// through the event manager. The dispatch mode only applies to callbacks
+// registered after the method is called. By default, event managers use
+// the mode set using the SetEventDispatchMode function.
+func (em *EventManager) SetDispatchMode(mode EventDispatchMode) error {
+	if err := mode.Validate(); err != nil {
+		return err
+	}
+
+	em.mu.Lock()
+	em.dispatchMode = mode
+	em.mu.Unlock()
+
+	return nil
+}
+
 // Attach registers a callback for an event notification.
 func (em *EventManager) Attach(event Event, callback EventCallback, userData interface{}) (EventID, error) {
 	return em.attach(event, callback, nil, userData)
 }
 
+// AttachWithPayload registers a callback for an event notification. Unlike
+// the callbacks registered using the Attach method, the provided callback
+// also receives the payload of the triggered event (e.g. the new playback
+// time for MediaPlayerTimeChanged events). See EventPayload for the list of
+// payload types associated with each event.
+func (em *EventManager) AttachWithPayload(event Event, callback EventPayloadCallback, userData interface{}) (EventID, error) {
+	if callback == nil {
+		return 0, ErrInvalidEventCallback
+	}
+
+	return em.register(&eventContext{
+		event:           event,
+		payloadCallback: callback,
+		userData:        userData,
+	})
+}
+
 // attach registers callbacks for an event notification.
 func (em *EventManager) attach(event Event, externalCallback EventCallback,
 	internalCallback internalEventCallback, userData interface{}) (EventID, error) {
+	if externalCallback == nil && internalCallback == nil {
+		return 0, ErrInvalidEventCallback
+	}
+
+	return em.register(&eventContext{
+		event:            event,
+		externalCallback: externalCallback,
+		internalCallback: internalCallback,
+		userData:         userData,
+	})
+}
+
+// register attaches the specified event context.
+func (em *EventManager) register(ctx *eventContext) (EventID, error) {
+	inst := em.inst
 	if err := inst.assertInit(); err != nil {
 		return 0, err
 	}
-	if externalCallback == nil && internalCallback == nil {
-		return 0, ErrInvalidEventCallback
+
+	// Internal callbacks receive the raw event data, which is only valid
+	// on the libVLC thread. Only external callbacks can be dispatched
+	// asynchronously.
+	async := em.DispatchMode() == EventDispatchAsync && ctx.internalCallback == nil
+	if async {
+		ctx.dispatcher = inst.dispatchers.acquire(unsafe.Pointer(em.manager))
 	}
 
-	id := inst.events.add(event, externalCallback, internalCallback, userData)
-	if C.eventAttach(em.manager, C.libvlc_event_type_t(event), C.ulong(id)) != 0 {
+	// Events emitted by the bindings are not attached to the libVLC
+	// event manager.
+	ctx.manager = unsafe.Pointer(em.manager)
+
+	id := inst.events.add(ctx)
+	if ctx.event.isBindingEvent() {
+		return id, nil
+	}
+	if C.eventAttach(em.manager, C.libvlc_event_type_t(ctx.event), C.ulong(id)) != 0 {
+		inst.events.remove(id)
+		if async {
+			inst.dispatchers.release(unsafe.Pointer(em.manager))
+		}
+
 		return 0, errOrDefault(getError(), ErrEventAttach)
 	}
 
@@ -57,6 +138,7 @@ func (em *EventManager) attach(event Event, externalCallback EventCallback,
 
 // Detach unregisters the specified event notification.
 func (em *EventManager) Detach(eventIDs ...EventID) {
+	inst := em.inst
 	if err := inst.assertInit(); err != nil {
 		return
 	}
@@ -68,24 +150,52 @@ func (em *EventManager) Detach(eventIDs ...EventID) {
 		}
 
 		inst.events.remove(eventID)
-		C.eventDetach(em.manager, C.libvlc_event_type_t(ctx.event), C.ulong(eventID))
+		if !ctx.event.isBindingEvent() {
+			C.eventDetach(em.manager, C.libvlc_event_type_t(ctx.event), C.ulong(eventID))
+		}
+
+		if ctx.dispatcher != nil {
+			inst.dispatchers.release(unsafe.Pointer(em.manager))
+		}
 	}
 }
 
-//export eventDispatch
-func eventDispatch(event *C.constev, userData unsafe.Pointer) {
-	if err := inst.assertInit(); err != nil {
+// detachEvents detaches all the events registered through the specified
+// libVLC event manager and stops its dispatcher. It is called when the
+// object owning the event manager is released.
+func (i *Instance) detachEvents(manager *C.libvlc_event_manager_t) {
+	if manager == nil || i.assertInit() != nil {
 		return
 	}
 
-	ctx, ok := inst.events.get(EventID(uintptr(userData)))
+	for id, ctx := range i.events.removeManager(unsafe.Pointer(manager)) {
+		if !ctx.event.isBindingEvent() {
+			C.eventDetach(manager, C.libvlc_event_type_t(ctx.event), C.ulong(id))
+		}
+		if ctx.detached != nil {
+			ctx.detached()
+		}
+	}
+	i.dispatchers.stop(unsafe.Pointer(manager))
+}
+
+//export eventDispatch
+func eventDispatch(event *C.constev, userData unsafe.Pointer) {
+	id := EventID(uintptr(userData))
+
+	inst, ctx, ok := instances.event(id)
 	if !ok {
 		return
 	}
 
-	// Execute external callback.
-	if ctx.externalCallback != nil {
-		ctx.externalCallback(ctx.event, ctx.userData)
+	// Execute external callbacks.
+	if ctx.externalCallback != nil || ctx.payloadCallback != nil {
+		var payload EventPayload
+		if ctx.payloadCallback != nil {
+			payload = parseEventPayload(inst, event, ctx.dispatcher != nil)
+		}
+
+		ctx.deliver(inst, id, payload)
 	}
 
 	// Execute internal callback.
@@ -93,3 +203,55 @@ func eventDispatch(event *C.constev, userData unsafe.Pointer) {
 		ctx.internalCallback(event, ctx.userData)
 	}
 }
+
+// emit triggers the specified event, emitted by the bindings, for all the
+// callbacks registered through the event manager.
+func (em *EventManager) emit(event Event, payload EventPayload) {
+	inst := em.inst
+	if err := inst.assertInit(); err != nil {
+		return
+	}
+
+	for id, ctx := range inst.events.find(unsafe.Pointer(em.manager), event) {
+		ctx.deliver(inst, id, payload)
+	}
+}
+
+// deliver executes the external callbacks of the event context, either
+// directly or through its dispatcher.
+func (ctx *eventContext) deliver(inst *Instance, id EventID, payload EventPayload) {
+	if ctx.dispatcher == nil {
+		ctx.dispatch(payload)
+		return
+	}
+
+	// The objects referenced by the payload are retained until the
+	// callbacks return, as libVLC may destroy them in the meantime.
+	retainEventPayload(payload)
+
+	queued := ctx.dispatcher.enqueue(func() {
+		defer releaseEventPayload(payload)
+
+		// Skip the event if it was detached in the meantime.
+		if err := inst.assertInit(); err != nil {
+			return
+		}
+		if current, ok := inst.events.get(id); !ok || current != ctx {
+			return
+		}
+
+		ctx.dispatch(payload)
+	})
+	if !queued {
+		releaseEventPayload(payload)
+	}
+}
+
+func (ctx *eventContext) dispatch(payload EventPayload) {
+	if ctx.externalCallback != nil {
+		ctx.externalCallback(ctx.event, ctx.userData)
+	}
+	if ctx.payloadCallback != nil {
+		ctx.payloadCallback(ctx.event, payload, ctx.userData)
+	}
+}
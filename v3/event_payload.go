@@ -0,0 +1,537 @@
+package vlc
+
+/*
+#cgo LDFLAGS: -lvlc
+#include <vlc/vlc.h>
+
+// Media events.
+static inline int evMetaKey(const libvlc_event_t* e) {
+	return e->u.media_meta_changed.meta_type;
+}
+static inline libvlc_media_t* evSubItem(const libvlc_event_t* e) {
+	return e->u.media_subitem_added.new_child;
+}
+static inline int64_t evMediaDuration(const libvlc_event_t* e) {
+	return e->u.media_duration_changed.new_duration;
+}
+static inline int evParsedStatus(const libvlc_event_t* e) {
+	return e->u.media_parsed_changed.new_status;
+}
+static inline libvlc_media_t* evFreedMedia(const libvlc_event_t* e) {
+	return e->u.media_freed.md;
+}
+static inline int evMediaState(const libvlc_event_t* e) {
+	return e->u.media_state_changed.new_state;
+}
+static inline libvlc_media_t* evSubItemTree(const libvlc_event_t* e) {
+	return e->u.media_subitemtree_added.item;
+}
+
+// Player events.
+static inline libvlc_media_t* evPlayerMedia(const libvlc_event_t* e) {
+	return e->u.media_player_media_changed.new_media;
+}
+static inline float evBuffering(const libvlc_event_t* e) {
+	return e->u.media_player_buffering.new_cache;
+}
+static inline libvlc_time_t evTime(const libvlc_event_t* e) {
+	return e->u.media_player_time_changed.new_time;
+}
+static inline float evPosition(const libvlc_event_t* e) {
+	return e->u.media_player_position_changed.new_position;
+}
+static inline int evSeekable(const libvlc_event_t* e) {
+	return e->u.media_player_seekable_changed.new_seekable;
+}
+static inline int evPausable(const libvlc_event_t* e) {
+	return e->u.media_player_pausable_changed.new_pausable;
+}
+static inline int evScrambled(const libvlc_event_t* e) {
+	return e->u.media_player_scrambled_changed.new_scrambled;
+}
+static inline int evTitle(const libvlc_event_t* e) {
+	return e->u.media_player_title_changed.new_title;
+}
+static inline int evChapter(const libvlc_event_t* e) {
+	return e->u.media_player_chapter_changed.new_chapter;
+}
+static inline const char* evSnapshotPath(const libvlc_event_t* e) {
+	return e->u.media_player_snapshot_taken.psz_filename;
+}
+static inline libvlc_time_t evLength(const libvlc_event_t* e) {
+	return e->u.media_player_length_changed.new_length;
+}
+static inline int evVoutCount(const libvlc_event_t* e) {
+	return e->u.media_player_vout.new_count;
+}
+static inline int evESType(const libvlc_event_t* e) {
+	return e->u.media_player_es_changed.i_type;
+}
+static inline int evESID(const libvlc_event_t* e) {
+	return e->u.media_player_es_changed.i_id;
+}
+static inline float evVolume(const libvlc_event_t* e) {
+	return e->u.media_player_audio_volume.volume;
+}
+static inline const char* evAudioDevice(const libvlc_event_t* e) {
+	return e->u.media_player_audio_device.device;
+}
+
+// Media list events.
+static inline libvlc_media_t* evListItem(const libvlc_event_t* e) {
+	switch (e->type) {
+	case libvlc_MediaListWillAddItem:
+		return (libvlc_media_t*)e->u.media_list_will_add_item.item;
+	case libvlc_MediaListItemDeleted:
+		return e->u.media_list_item_deleted.item;
+	case libvlc_MediaListWillDeleteItem:
+		return e->u.media_list_will_delete_item.item;
+	}
+	return e->u.media_list_item_added.item;
+}
+static inline int evListIndex(const libvlc_event_t* e) {
+	switch (e->type) {
+	case libvlc_MediaListWillAddItem:
+		return e->u.media_list_will_add_item.index;
+	case libvlc_MediaListItemDeleted:
+		return e->u.media_list_item_deleted.index;
+	case libvlc_MediaListWillDeleteItem:
+		return e->u.media_list_will_delete_item.index;
+	}
+	return e->u.media_list_item_added.index;
+}
+
+// List player events.
+static inline libvlc_media_t* evNextItem(const libvlc_event_t* e) {
+	return e->u.media_list_player_next_item_set.item;
+}
+
+// Renderer discoverer events.
+static inline libvlc_renderer_item_t* evRenderer(const libvlc_event_t* e) {
+	if (e->type == libvlc_RendererDiscovererItemDeleted) {
+		return e->u.renderer_discoverer_item_deleted.item;
+	}
+	return e->u.renderer_discoverer_item_added.item;
+}
+
+// VLM events.
+static inline const char* evVlmMediaName(const libvlc_event_t* e) {
+	return e->u.vlm_media_event.psz_media_name;
+}
+static inline const char* evVlmInstanceName(const libvlc_event_t* e) {
+	return e->u.vlm_media_event.psz_instance_name;
+}
+*/
+import "C"
+import (
+	"time"
+)
+
+// EventPayload contains event specific information. The concrete type of
+// the payload depends on the event it was delivered with. The payloads are
+// delivered as pointers (e.g. *TimePayload), so type switches and type
+// assertions must use the pointer types.
+//
+//	Payload types by event:
+//	  - MetaPayload: MediaMetaChanged
+//	  - MediaPayload: MediaSubItemAdded, MediaSubItemTreeAdded, MediaFreed,
+//	    MediaPlayerMediaChanged, MediaListPlayerNextItemSet
+//	  - DurationPayload: MediaDurationChanged, MediaPlayerLengthChanged
+//	  - ParseStatusPayload: MediaParsedChanged
+//	  - StatePayload: MediaStateChanged
+//	  - BufferingPayload: MediaPlayerBuffering
+//	  - TimePayload: MediaPlayerTimeChanged
+//	  - PositionPayload: MediaPlayerPositionChanged
+//	  - FlagPayload: MediaPlayerSeekableChanged, MediaPlayerPausableChanged,
+//	    MediaPlayerScrambledChanged
+//	  - TitlePayload: MediaPlayerTitleChanged
+//	  - ChapterPayload: MediaPlayerChapterChanged
+//	  - SnapshotPayload: MediaPlayerSnapshotTaken
+//	  - VideoOutputPayload: MediaPlayerVout
+//	  - TrackPayload: MediaPlayerESAdded, MediaPlayerESDeleted,
+//	    MediaPlayerESSelected
+//	  - VolumePayload: MediaPlayerAudioVolume
+//	  - AudioDevicePayload: MediaPlayerAudioDevice
+//	  - MediaListItemPayload: MediaListItemAdded, MediaListWillAddItem,
+//	    MediaListItemDeleted, MediaListWillDeleteItem
+//	  - RendererPayload: RendererDiscovererItemAdded,
+//	    RendererDiscovererItemDeleted
+//	  - VlmPayload: VlmMediaAdded ... VlmMediaInstanceStatusError
+//	  - RecordingPayload: MediaPlayerRecordingStarted,
+//	    MediaPlayerRecordingStopped, MediaPlayerRecordingError
+//
+// Events which do not carry additional information (e.g. MediaPlayerPlaying)
+// are delivered with a `nil` payload.
+type EventPayload interface {
+	eventPayload()
+}
+
+// EventPayloadCallback represents an event notification callback function
+// which receives the payload of the triggered event.
+type EventPayloadCallback func(Event, EventPayload, interface{})
+
+// MetaPayload contains the key of the media metadata which changed.
+type MetaPayload struct {
+	Key MediaMetaKey
+}
+
+// MediaPayload contains the media instance an event refers to.
+//
+//	NOTE: The media instance is owned by libVLC and it is only guaranteed
+//	to be valid for the duration of the callback. Do not call Release on it.
+//	MediaFreed events delivered asynchronously do not contain the media
+//	instance, as it is destroyed once the event is triggered.
+type MediaPayload struct {
+	Media *Media
+}
+
+// DurationPayload contains the new duration of a media instance.
+type DurationPayload struct {
+	Duration time.Duration
+}
+
+// ParseStatusPayload contains the new parsing status of a media instance.
+type ParseStatusPayload struct {
+	Status MediaParseStatus
+}
+
+// StatePayload contains the new state of a media instance.
+type StatePayload struct {
+	State MediaState
+}
+
+// BufferingPayload contains the buffering progress of a player,
+// as a percentage between 0.0 and 100.0.
+type BufferingPayload struct {
+	Progress float32
+}
+
+// TimePayload contains the new playback time of a player.
+type TimePayload struct {
+	Time time.Duration
+}
+
+// PositionPayload contains the new playback position of a player,
+// as a float percentage between 0.0 and 1.0.
+type PositionPayload struct {
+	Position float32
+}
+
+// FlagPayload contains the new value of a boolean player property
+// (e.g. seekable, pausable, scrambled).
+type FlagPayload struct {
+	Value bool
+}
+
+// TitlePayload contains the index of the new player title.
+type TitlePayload struct {
+	Index int
+}
+
+// ChapterPayload contains the index of the new player chapter.
+type ChapterPayload struct {
+	Index int
+}
+
+// SnapshotPayload contains the path of a newly saved video snapshot.
+type SnapshotPayload struct {
+	Path string
+}
+
+// VideoOutputPayload contains the new number of video outputs of a player.
+type VideoOutputPayload struct {
+	Count int
+}
+
+// TrackPayload identifies the elementary stream (track)
+// an event refers to.
+type TrackPayload struct {
+	TrackType MediaTrackType
+	TrackID   int
+}
+
+// VolumePayload contains the new audio volume of a player.
+type VolumePayload struct {
+	Volume float32
+}
+
+// AudioDevicePayload contains the identifier of the new audio
+// output device of a player.
+type AudioDevicePayload struct {
+	Device string
+}
+
+// MediaListItemPayload contains the media instance an event refers to,
+// along with its index in the media list.
+//
+//	NOTE: The media instance is owned by libVLC and it is only guaranteed
+//	to be valid for the duration of the callback. Do not call Release on it.
+type MediaListItemPayload struct {
+	Media *Media
+	Index int
+}
+
+// RendererPayload contains the renderer an event refers to.
+//
+//	NOTE: The renderer is owned by libVLC and it is only guaranteed
+//	to be valid for the duration of the callback.
+type RendererPayload struct {
+	Renderer *Renderer
+}
+
+// VlmPayload contains the names of the VLM media and media
+// instance an event refers to.
+type VlmPayload struct {
+	MediaName    string
+	InstanceName string
+}
+
+// RecordingPayload contains information about the recording session of
+// a player. The Err field is only set for MediaPlayerRecordingError events.
+type RecordingPayload struct {
+	Path string
+	Err  error
+}
+
+func (MetaPayload) eventPayload()          {}
+func (MediaPayload) eventPayload()         {}
+func (DurationPayload) eventPayload()      {}
+func (ParseStatusPayload) eventPayload()   {}
+func (StatePayload) eventPayload()         {}
+func (BufferingPayload) eventPayload()     {}
+func (TimePayload) eventPayload()          {}
+func (PositionPayload) eventPayload()      {}
+func (FlagPayload) eventPayload()          {}
+func (TitlePayload) eventPayload()         {}
+func (ChapterPayload) eventPayload()       {}
+func (SnapshotPayload) eventPayload()      {}
+func (VideoOutputPayload) eventPayload()   {}
+func (TrackPayload) eventPayload()         {}
+func (VolumePayload) eventPayload()        {}
+func (AudioDevicePayload) eventPayload()   {}
+func (MediaListItemPayload) eventPayload() {}
+func (RendererPayload) eventPayload()      {}
+func (VlmPayload) eventPayload()           {}
+func (RecordingPayload) eventPayload()     {}
+
+// eventPayloadHolder is implemented by the payloads which reference libVLC
+// objects. The objects are retained while the payloads are delivered
+// asynchronously, so they remain valid until the callbacks return.
+type eventPayloadHolder interface {
+	// retain retains the referenced objects.
+	retain()
+
+	// release releases the referenced objects, which must not be used
+	// after the call.
+	release()
+
+	// clone returns a copy of the payload, which references the same
+	// objects, without retaining them.
+	clone() EventPayload
+}
+
+func (p *MediaPayload) retain() {
+	retainPayloadMedia(p.Media)
+}
+
+func (p *MediaPayload) release() {
+	releasePayloadMedia(p.Media)
+}
+
+func (p *MediaPayload) clone() EventPayload {
+	return &MediaPayload{Media: clonePayloadMedia(p.Media)}
+}
+
+func (p *MediaListItemPayload) retain() {
+	retainPayloadMedia(p.Media)
+}
+
+func (p *MediaListItemPayload) release() {
+	releasePayloadMedia(p.Media)
+}
+
+func (p *MediaListItemPayload) clone() EventPayload {
+	return &MediaListItemPayload{Media: clonePayloadMedia(p.Media), Index: p.Index}
+}
+
+func (p *RendererPayload) retain() {
+	p.Renderer.hold()
+}
+
+func (p *RendererPayload) release() {
+	p.Renderer.release()
+	if p.Renderer != nil {
+		p.Renderer.renderer = nil
+	}
+}
+
+func (p *RendererPayload) clone() EventPayload {
+	if p.Renderer == nil {
+		return &RendererPayload{}
+	}
+
+	return &RendererPayload{Renderer: &Renderer{renderer: p.Renderer.renderer}}
+}
+
+func retainPayloadMedia(m *Media) {
+	if m != nil && m.media != nil {
+		C.libvlc_media_retain(m.media)
+	}
+}
+
+func releasePayloadMedia(m *Media) {
+	if m != nil && m.media != nil {
+		C.libvlc_media_release(m.media)
+		m.media = nil
+	}
+}
+
+func clonePayloadMedia(m *Media) *Media {
+	if m == nil {
+		return nil
+	}
+
+	return &Media{media: m.media, inst: m.inst}
+}
+
+// retainEventPayload retains the libVLC objects referenced by the payload.
+func retainEventPayload(payload EventPayload) {
+	if holder, ok := payload.(eventPayloadHolder); ok {
+		holder.retain()
+	}
+}
+
+// releaseEventPayload releases the libVLC objects referenced by the payload.
+func releaseEventPayload(payload EventPayload) {
+	if holder, ok := payload.(eventPayloadHolder); ok {
+		holder.release()
+	}
+}
+
+// holdEventPayload returns a copy of the payload which retains the libVLC
+// objects it references. Payloads which do not reference libVLC objects
+// are returned as is.
+func holdEventPayload(payload EventPayload) EventPayload {
+	holder, ok := payload.(eventPayloadHolder)
+	if !ok {
+		return payload
+	}
+
+	payload = holder.clone()
+	retainEventPayload(payload)
+	return payload
+}
+
+// parseEventPayload returns the payload of the specified event. If async is
+// true, the payload is delivered after the libVLC callback returns, so the
+// objects which are destroyed once the event is triggered are omitted.
+func parseEventPayload(inst *Instance, event *C.libvlc_event_t, async bool) EventPayload {
+	if event == nil {
+		return nil
+	}
+
+	switch Event(event._type) {
+	// Media events.
+	case MediaMetaChanged:
+		return &MetaPayload{Key: MediaMetaKey(C.evMetaKey(event))}
+	case MediaSubItemAdded:
+		return newMediaPayload(inst, C.evSubItem(event))
+	case MediaDurationChanged:
+		return &DurationPayload{
+			Duration: time.Duration(C.evMediaDuration(event)) * time.Millisecond,
+		}
+	case MediaParsedChanged:
+		return &ParseStatusPayload{Status: MediaParseStatus(C.evParsedStatus(event))}
+	case MediaFreed:
+		if async {
+			return &MediaPayload{}
+		}
+		return newMediaPayload(inst, C.evFreedMedia(event))
+	case MediaStateChanged:
+		return &StatePayload{State: MediaState(C.evMediaState(event))}
+	case MediaSubItemTreeAdded:
+		return newMediaPayload(inst, C.evSubItemTree(event))
+
+	// Player events.
+	case MediaPlayerMediaChanged:
+		return newMediaPayload(inst, C.evPlayerMedia(event))
+	case MediaPlayerBuffering:
+		return &BufferingPayload{Progress: float32(C.evBuffering(event))}
+	case MediaPlayerTimeChanged:
+		return &TimePayload{Time: time.Duration(C.evTime(event)) * time.Millisecond}
+	case MediaPlayerPositionChanged:
+		return &PositionPayload{Position: float32(C.evPosition(event))}
+	case MediaPlayerSeekableChanged:
+		return &FlagPayload{Value: C.evSeekable(event) != 0}
+	case MediaPlayerPausableChanged:
+		return &FlagPayload{Value: C.evPausable(event) != 0}
+	case MediaPlayerScrambledChanged:
+		return &FlagPayload{Value: C.evScrambled(event) != 0}
+	case MediaPlayerTitleChanged:
+		return &TitlePayload{Index: int(C.evTitle(event))}
+	case MediaPlayerChapterChanged:
+		return &ChapterPayload{Index: int(C.evChapter(event))}
+	case MediaPlayerSnapshotTaken:
+		return &SnapshotPayload{Path: C.GoString(C.evSnapshotPath(event))}
+	case MediaPlayerLengthChanged:
+		return &DurationPayload{Duration: time.Duration(C.evLength(event)) * time.Millisecond}
+	case MediaPlayerVout:
+		return &VideoOutputPayload{Count: int(C.evVoutCount(event))}
+	case MediaPlayerESAdded, MediaPlayerESDeleted, MediaPlayerESSelected:
+		return &TrackPayload{
+			TrackType: MediaTrackType(C.evESType(event)),
+			TrackID:   int(C.evESID(event)),
+		}
+	case MediaPlayerAudioVolume:
+		return &VolumePayload{Volume: float32(C.evVolume(event))}
+	case MediaPlayerAudioDevice:
+		return &AudioDevicePayload{Device: C.GoString(C.evAudioDevice(event))}
+
+	// Media list events.
+	case MediaListItemAdded, MediaListWillAddItem,
+		MediaListItemDeleted, MediaListWillDeleteItem:
+		var media *Media
+		if cMedia := C.evListItem(event); cMedia != nil {
+			media = &Media{media: cMedia, inst: inst}
+		}
+
+		return &MediaListItemPayload{
+			Media: media,
+			Index: int(C.evListIndex(event)),
+		}
+
+	// List player events.
+	case MediaListPlayerNextItemSet:
+		return newMediaPayload(inst, C.evNextItem(event))
+
+	// Renderer discoverer events.
+	case RendererDiscovererItemAdded, RendererDiscovererItemDeleted:
+		var renderer *Renderer
+		if cRenderer := C.evRenderer(event); cRenderer != nil {
+			renderer = &Renderer{renderer: cRenderer}
+		}
+
+		return &RendererPayload{Renderer: renderer}
+
+	// VLM events.
+	case VlmMediaAdded, VlmMediaRemoved, VlmMediaChanged,
+		VlmMediaInstanceStarted, VlmMediaInstanceStopped,
+		VlmMediaInstanceStatusInit, VlmMediaInstanceStatusOpening,
+		VlmMediaInstanceStatusPlaying, VlmMediaInstanceStatusPause,
+		VlmMediaInstanceStatusEnd, VlmMediaInstanceStatusError:
+		return &VlmPayload{
+			MediaName:    C.GoString(C.evVlmMediaName(event)),
+			InstanceName: C.GoString(C.evVlmInstanceName(event)),
+		}
+	}
+
+	return nil
+}
+
+func newMediaPayload(inst *Instance, cMedia *C.libvlc_media_t) *MediaPayload {
+	if cMedia == nil {
+		return &MediaPayload{}
+	}
+
+	return &MediaPayload{Media: &Media{media: cMedia, inst: inst}}
+}
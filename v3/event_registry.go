@@ -3,7 +3,11 @@ package vlc
 // #cgo LDFLAGS: -lvlc
 // #include <vlc/vlc.h>
 import "C"
-import "sync"
+import (
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
 
 // EventID uniquely identifies a registered event.
 type EventID uint64
@@ -16,15 +20,25 @@ type internalEventCallback func(*C.libvlc_event_t, interface{})
 type eventContext struct {
 	event            Event
 	externalCallback EventCallback
+	payloadCallback  EventPayloadCallback
 	internalCallback internalEventCallback
 	userData         interface{}
+	dispatcher       *eventDispatcher
+	manager          unsafe.Pointer
+
+	// Invoked when the event is detached because the object owning the
+	// event manager, or the instance, is released.
+	detached func()
 }
 
+// eventSequence is used to generate event identifiers which are
+// unique across all libVLC instances.
+var eventSequence uint64
+
 type eventRegistry struct {
 	sync.RWMutex
 
 	contexts map[EventID]*eventContext
-	sequence EventID
 }
 
 func newEventRegistry() *eventRegistry {
@@ -45,21 +59,13 @@ func (er *eventRegistry) get(id EventID) (*eventContext, bool) {
 	return ctx, ok
 }
 
-func (er *eventRegistry) add(event Event, externalCallback EventCallback,
-	internalCallback internalEventCallback, userData interface{}) EventID {
-	er.Lock()
-
-	er.sequence++
-	id := er.sequence
-
-	er.contexts[id] = &eventContext{
-		event:            event,
-		externalCallback: externalCallback,
-		internalCallback: internalCallback,
-		userData:         userData,
-	}
+func (er *eventRegistry) add(ctx *eventContext) EventID {
+	id := EventID(atomic.AddUint64(&eventSequence, 1))
 
+	er.Lock()
+	er.contexts[id] = ctx
 	er.Unlock()
+
 	return id
 }
 
@@ -72,3 +78,42 @@ func (er *eventRegistry) remove(id EventID) {
 	delete(er.contexts, id)
 	er.Unlock()
 }
+
+func (er *eventRegistry) find(manager unsafe.Pointer, event Event) map[EventID]*eventContext {
+	er.RLock()
+	defer er.RUnlock()
+
+	contexts := map[EventID]*eventContext{}
+	for id, ctx := range er.contexts {
+		if ctx.manager == manager && ctx.event == event {
+			contexts[id] = ctx
+		}
+	}
+
+	return contexts
+}
+
+func (er *eventRegistry) removeManager(manager unsafe.Pointer) map[EventID]*eventContext {
+	er.Lock()
+	defer er.Unlock()
+
+	contexts := map[EventID]*eventContext{}
+	for id, ctx := range er.contexts {
+		if ctx.manager == manager {
+			contexts[id] = ctx
+			delete(er.contexts, id)
+		}
+	}
+
+	return contexts
+}
+
+func (er *eventRegistry) removeAll() map[EventID]*eventContext {
+	er.Lock()
+	defer er.Unlock()
+
+	contexts := er.contexts
+	er.contexts = map[EventID]*eventContext{}
+
+	return contexts
+}
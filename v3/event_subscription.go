@@ -0,0 +1,141 @@
+package vlc
+
+import (
+	"context"
+	"sync"
+)
+
+// EventSubscriptionBufferSize is the maximum number of undelivered events
+// which can be queued by an event subscription. See EventManager.Subscribe.
+const EventSubscriptionBufferSize = 128
+
+// EventInfo contains information regarding a triggered event.
+type EventInfo struct {
+	Event   Event        // The triggered event.
+	Payload EventPayload // Event specific information. Can be `nil`.
+
+	// Number of events dropped by the subscription since the previously
+	// delivered event, because the consumer was not keeping up.
+	Dropped int
+}
+
+// Release releases the media instances and renderers retained by the
+// payload of the event. Call it once the payload is no longer needed.
+// The media instances and renderers must not be used after the call.
+func (ei EventInfo) Release() {
+	releaseEventPayload(ei.Payload)
+}
+
+// Subscribe attaches the specified events and delivers their notifications,
+// along with their payloads, on the returned channel. The events are detached
+// and the channel is closed when the provided context is cancelled, or when
+// the object owning the event manager (e.g. the player) or the instance is
+// released. Until then, the subscription is kept alive by a goroutine, so
+// the context should be cancelled once the events are no longer needed.
+//
+// The notifications are queued in a buffer which can hold up to
+// EventSubscriptionBufferSize events. If the buffer is full, the oldest
+// queued event is dropped in order to make room for the new one, so that a
+// slow consumer never stalls the libVLC event thread. The number of dropped
+// events is reported through the Dropped field of the next delivered event.
+//
+//	NOTE: The media instances and renderers contained in event payloads are
+//	retained by the subscription, so they remain valid after the event is
+//	received. Call the Release method of the received events which contain
+//	media instances or renderers once they are no longer needed. The events
+//	dropped by the subscription are released automatically.
+func (em *EventManager) Subscribe(ctx context.Context, events ...Event) (<-chan EventInfo, error) {
+	if len(events) == 0 {
+		return nil, ErrInvalid
+	}
+
+	sub := &eventSubscription{
+		queue: make(chan EventInfo, EventSubscriptionBufferSize),
+		done:  make(chan struct{}),
+	}
+
+	// Attach events.
+	eventIDs := make([]EventID, 0, len(events))
+	for _, event := range events {
+		eventID, err := em.register(&eventContext{
+			event:           event,
+			payloadCallback: sub.push,
+			detached:        sub.close,
+		})
+		if err != nil {
+			em.Detach(eventIDs...)
+			return nil, err
+		}
+
+		eventIDs = append(eventIDs, eventID)
+	}
+
+	// Detach events when the context is cancelled or when the events are
+	// detached by the release of their owner.
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-sub.done:
+		}
+
+		em.Detach(eventIDs...)
+		sub.close()
+	}()
+
+	return sub.queue, nil
+}
+
+type eventSubscription struct {
+	sync.Mutex
+
+	queue   chan EventInfo
+	done    chan struct{}
+	dropped int
+	closed  bool
+}
+
+func (es *eventSubscription) push(event Event, payload EventPayload, _ interface{}) {
+	es.Lock()
+	defer es.Unlock()
+
+	if es.closed {
+		return
+	}
+
+	// The payload is delivered after the callback returns, so the objects
+	// it references must be retained. The media instances of MediaFreed
+	// events are destroyed once the event is triggered.
+	if event == MediaFreed {
+		payload = &MediaPayload{}
+	}
+	info := EventInfo{Event: event, Payload: holdEventPayload(payload)}
+	for {
+		info.Dropped = es.dropped
+
+		select {
+		case es.queue <- info:
+			es.dropped = 0
+			return
+		default:
+		}
+
+		// The queue is full. Drop the oldest event.
+		select {
+		case dropped := <-es.queue:
+			dropped.Release()
+			es.dropped += dropped.Dropped + 1
+		default:
+		}
+	}
+}
+
+func (es *eventSubscription) close() {
+	es.Lock()
+	defer es.Unlock()
+
+	if !es.closed {
+		es.closed = true
+		close(es.queue)
+		close(es.done)
+	}
+}
@@ -143,3 +143,27 @@ const (
 	VlmMediaInstanceStatusEnd
 	VlmMediaInstanceStatusError
 )
+
+// Recording events. Unlike the other events, which are emitted by libVLC,
+// these events are emitted by the bindings.
+const (
+	// MediaPlayerRecordingStarted is triggered when a recording session
+	// of a player is started.
+	MediaPlayerRecordingStarted Event = bindingEventBase + iota
+
+	// MediaPlayerRecordingStopped is triggered when a recording session
+	// of a player is stopped.
+	MediaPlayerRecordingStopped
+
+	// MediaPlayerRecordingError is triggered when a recording session
+	// of a player encounters an error.
+	MediaPlayerRecordingError
+)
+
+// bindingEventBase represents the first value of the events which are
+// emitted by the bindings.
+const bindingEventBase Event = 0x1000
+
+func (e Event) isBindingEvent() bool {
+	return e >= bindingEventBase
+}
@@ -0,0 +1,165 @@
+package vlc
+
+/*
+#cgo LDFLAGS: -lvlc
+#include <vlc/vlc.h>
+
+extern void exitCB(void*);
+
+static inline void exitSetHandler(libvlc_instance_t* instance, void* data) {
+	libvlc_set_exit_handler(instance, data != NULL ? exitCB : NULL, data);
+}
+*/
+import "C"
+import (
+	"sync"
+	"unsafe"
+)
+
+// instanceExit keeps track of the exit requests of an instance.
+type instanceExit struct {
+	sync.Mutex
+
+	id        objectID
+	done      chan struct{}
+	closed    bool
+	requested bool
+	callbacks []func()
+}
+
+func newInstanceExit() *instanceExit {
+	return &instanceExit{done: make(chan struct{})}
+}
+
+// close closes the done channel of the instance. If requested is true, the
+// registered exit callbacks are invoked.
+func (ie *instanceExit) close(requested bool) {
+	ie.Lock()
+	if ie.closed {
+		ie.Unlock()
+		return
+	}
+	ie.closed, ie.requested = true, requested
+
+	callbacks := ie.callbacks
+	ie.callbacks = nil
+	ie.Unlock()
+
+	close(ie.done)
+	if !requested {
+		return
+	}
+
+	// The callbacks are invoked on a separate goroutine, as libVLC
+	// functions cannot be called from within the exit handler.
+	go func() {
+		for _, cb := range callbacks {
+			cb()
+		}
+	}()
+}
+
+// OnExit registers a callback which is invoked when the default instance
+// is requested to exit (e.g. by a user interface started using the
+// StartUserInterface function). The callback is invoked on a separate
+// goroutine. The application should release the instance once the exit
+// is requested.
+func OnExit(cb func()) error {
+	return inst.OnExit(cb)
+}
+
+// Done returns a channel which is closed when the default instance is
+// requested to exit by libVLC or when it is released.
+//
+//	NOTE: If the default instance is not initialized, the method returns a
+//	nil channel, which blocks forever. Obtain the channel after calling the
+//	Init function.
+func Done() <-chan struct{} {
+	if inst == nil {
+		return nil
+	}
+
+	return inst.Done()
+}
+
+// OnExit registers a callback which is invoked when the instance is
+// requested to exit (e.g. by a user interface started using the
+// StartUserInterface method). The callback is invoked on a separate
+// goroutine. If the exit was already requested, the callback is
+// invoked immediately.
+//
+//	NOTE: The callback is not invoked if the instance is released before
+//	an exit is requested.
+func (i *Instance) OnExit(cb func()) error {
+	if err := i.assertInit(); err != nil {
+		return err
+	}
+	if cb == nil {
+		return ErrInvalid
+	}
+
+	ie := i.exit
+	ie.Lock()
+	defer ie.Unlock()
+
+	if ie.closed {
+		if ie.requested {
+			go cb()
+		}
+		return nil
+	}
+	ie.callbacks = append(ie.callbacks, cb)
+
+	return nil
+}
+
+// Done returns a channel which is closed when the instance is requested
+// to exit by libVLC or when the instance is released.
+func (i *Instance) Done() <-chan struct{} {
+	if i == nil || i.exit == nil {
+		done := make(chan struct{})
+		close(done)
+		return done
+	}
+
+	return i.exit.done
+}
+
+// ExitRequested returns true if the instance was requested to exit
+// by libVLC.
+func (i *Instance) ExitRequested() bool {
+	if i == nil || i.exit == nil {
+		return false
+	}
+
+	i.exit.Lock()
+	defer i.exit.Unlock()
+
+	return i.exit.requested
+}
+
+func (i *Instance) setExitHandler() {
+	i.exit.id = i.objects.add(i.exit)
+	C.exitSetHandler(i.handle, unsafe.Pointer(i.exit.id))
+}
+
+func (i *Instance) unsetExitHandler() {
+	// No exit handler invocation is in progress once the handler is unset.
+	C.exitSetHandler(i.handle, nil)
+	i.exit.close(false)
+
+	i.objects.decRefs(i.exit.id)
+	i.exit.id = nil
+}
+
+//export exitCB
+func exitCB(data unsafe.Pointer) {
+	_, obj, ok := instances.object(data)
+	if !ok {
+		return
+	}
+
+	if ie, ok := obj.(*instanceExit); ok {
+		ie.close(true)
+	}
+}
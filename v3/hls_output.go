@@ -0,0 +1,101 @@
+package vlc
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// HLSOutput represents a std stream output module, which packages the
+// stream as HTTP Live Streaming segments, using the livehttp access output
+// and the ts muxer. The segments and the playlist are written to the local
+// filesystem, from where they can be served by any HTTP server.
+//
+// The segment path and the index URL are templates in which a sequence of
+// # characters is replaced with the zero padded number of the segment
+// (e.g. /var/www/live/stream-########.ts).
+type HLSOutput struct {
+	// Path template of the segment files.
+	SegmentPath string
+
+	// Duration of the segments. The duration is rounded to seconds. If 0,
+	// the libVLC default (10 seconds) is used.
+	SegmentDuration time.Duration
+
+	// Number of segments listed in the playlist. If 0, all the segments
+	// are listed.
+	Segments uint
+
+	// Path of the playlist (index) file.
+	IndexPath string
+
+	// URL template of the segments, as listed in the playlist (e.g.
+	// http://example.com/live/stream-########.ts). If empty, the segment
+	// path is used.
+	IndexURL string
+
+	// Delete the segments which are no longer listed in the playlist.
+	// Useful for live streams, in combination with the Segments field.
+	DeleteSegments bool
+}
+
+// NewHLSOutput returns a new HLS output which writes the playlist and the
+// segments to the specified directory. The playlist is named name.m3u8 and
+// the segments are named name-########.ts. The segments are listed in the
+// playlist using paths relative to the directory, so the directory can be
+// published as is.
+func NewHLSOutput(dir, name string) *HLSOutput {
+	segment := name + "-########.ts"
+
+	return &HLSOutput{
+		SegmentPath: filepath.Join(dir, segment),
+		IndexPath:   filepath.Join(dir, name+".m3u8"),
+		IndexURL:    segment,
+	}
+}
+
+// PlaylistPath returns the path of the generated playlist file.
+func (h *HLSOutput) PlaylistPath() string {
+	return h.IndexPath
+}
+
+// Validate returns an error if the configuration of the module is not valid.
+func (h *HLSOutput) Validate() error {
+	if h.SegmentPath == "" {
+		return fmt.Errorf("%w: livehttp: no segment path specified", ErrInvalidStreamOutput)
+	}
+	if !strings.Contains(h.SegmentPath, "#") {
+		return fmt.Errorf("%w: livehttp: segment path %q does not contain a segment number template", ErrInvalidStreamOutput, h.SegmentPath)
+	}
+	if h.IndexURL != "" && !strings.Contains(h.IndexURL, "#") {
+		return fmt.Errorf("%w: livehttp: index URL %q does not contain a segment number template", ErrInvalidStreamOutput, h.IndexURL)
+	}
+	if h.IndexPath == "" {
+		return fmt.Errorf("%w: livehttp: no index path specified", ErrInvalidStreamOutput)
+	}
+	if h.SegmentDuration < 0 || (h.SegmentDuration > 0 && h.SegmentDuration < time.Second) {
+		return fmt.Errorf("%w: livehttp: invalid segment duration %s", ErrInvalidStreamOutput, h.SegmentDuration)
+	}
+
+	return nil
+}
+
+func (h *HLSOutput) render() string {
+	access := &soutOptions{}
+	access.addUint("seglen", uint(h.SegmentDuration.Round(time.Second)/time.Second))
+	access.addUint("numsegs", h.Segments)
+	access.add("index", h.IndexPath)
+	access.add("index-url", h.IndexURL)
+
+	// libVLC deletes old segments by default, so the flag is always rendered.
+	access.addFlag("delsegs", h.DeleteSegments)
+	access.addFlag("nodelsegs", !h.DeleteSegments)
+
+	opts := &soutOptions{}
+	opts.opts = append(opts.opts, "access="+access.render("livehttp"))
+	opts.add("mux", "ts")
+	opts.add("dst", h.SegmentPath)
+
+	return opts.render("std")
+}
@@ -0,0 +1,108 @@
+package vlc
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHLSOutputRender(t *testing.T) {
+	tests := []struct {
+		name string
+		hls  *HLSOutput
+		want string
+	}{
+		{
+			name: "defaults",
+			hls:  &HLSOutput{SegmentPath: "live-###.ts", IndexPath: "live.m3u8"},
+			want: "#std{access=livehttp{index=live.m3u8,nodelsegs},mux=ts,dst=live-###.ts}",
+		},
+		{
+			name: "segment length",
+			hls:  &HLSOutput{SegmentPath: "live-###.ts", IndexPath: "live.m3u8", SegmentDuration: 4 * time.Second},
+			want: "#std{access=livehttp{seglen=4,index=live.m3u8,nodelsegs},mux=ts,dst=live-###.ts}",
+		},
+		{
+			name: "rounded segment length",
+			hls:  &HLSOutput{SegmentPath: "live-###.ts", IndexPath: "live.m3u8", SegmentDuration: 2500 * time.Millisecond},
+			want: "#std{access=livehttp{seglen=3,index=live.m3u8,nodelsegs},mux=ts,dst=live-###.ts}",
+		},
+		{
+			name: "live window",
+			hls:  &HLSOutput{SegmentPath: "live-###.ts", IndexPath: "live.m3u8", Segments: 5, DeleteSegments: true},
+			want: "#std{access=livehttp{numsegs=5,index=live.m3u8,delsegs},mux=ts,dst=live-###.ts}",
+		},
+		{
+			name: "index url",
+			hls: &HLSOutput{
+				SegmentPath: "/var/www/live/stream-########.ts",
+				IndexPath:   "/var/www/live/stream.m3u8",
+				IndexURL:    "http://example.com/live/stream-########.ts",
+			},
+			want: "#std{access=livehttp{index=/var/www/live/stream.m3u8," +
+				`index-url="http://example.com/live/stream-########.ts",nodelsegs},` +
+				"mux=ts,dst=/var/www/live/stream-########.ts}",
+		},
+		{
+			name: "quoted paths",
+			hls:  &HLSOutput{SegmentPath: "my live-##.ts", IndexPath: "my live.m3u8", IndexURL: "my%20live-##.ts"},
+			want: `#std{access=livehttp{index="my live.m3u8",index-url=my%20live-##.ts,nodelsegs},mux=ts,dst="my live-##.ts"}`,
+		},
+		{
+			name: "new output",
+			hls:  NewHLSOutput("live", "stream"),
+			want: "#std{access=livehttp{index=" + filepath.Join("live", "stream.m3u8") +
+				",index-url=stream-########.ts,nodelsegs},mux=ts,dst=" + filepath.Join("live", "stream-########.ts") + "}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := NewStreamOutput(test.hls).Render()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestHLSOutputPlaylistPath(t *testing.T) {
+	hls := NewHLSOutput("live", "stream")
+	if want := filepath.Join("live", "stream.m3u8"); hls.PlaylistPath() != want {
+		t.Errorf("got %s, want %s", hls.PlaylistPath(), want)
+	}
+	if want := filepath.Join("live", "stream-########.ts"); hls.SegmentPath != want {
+		t.Errorf("segment path: got %s, want %s", hls.SegmentPath, want)
+	}
+
+	hls.IndexPath = "index.m3u8"
+	if hls.PlaylistPath() != "index.m3u8" {
+		t.Errorf("got %s, want index.m3u8", hls.PlaylistPath())
+	}
+}
+
+func TestHLSOutputValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		hls  *HLSOutput
+	}{
+		{name: "no segment path", hls: &HLSOutput{IndexPath: "live.m3u8"}},
+		{name: "no segment template", hls: &HLSOutput{SegmentPath: "live.ts", IndexPath: "live.m3u8"}},
+		{name: "no index url template", hls: &HLSOutput{SegmentPath: "live-##.ts", IndexPath: "live.m3u8", IndexURL: "live.ts"}},
+		{name: "no index path", hls: &HLSOutput{SegmentPath: "live-##.ts"}},
+		{name: "negative duration", hls: &HLSOutput{SegmentPath: "live-##.ts", IndexPath: "live.m3u8", SegmentDuration: -time.Second}},
+		{name: "subsecond duration", hls: &HLSOutput{SegmentPath: "live-##.ts", IndexPath: "live.m3u8", SegmentDuration: time.Millisecond}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := NewStreamOutput(test.hls).Render(); !errors.Is(err, ErrInvalidStreamOutput) {
+				t.Errorf("expected ErrInvalidStreamOutput, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package vlc
+
+import (
+	"strings"
+)
+
+// languageCodes maps ISO 639-2 codes and English language names to
+// ISO 639-1 codes, for the most commonly used languages.
+var languageCodes = map[string]string{
+	"ara": "ar", "arabic": "ar",
+	"bul": "bg", "bulgarian": "bg",
+	"cat": "ca", "catalan": "ca",
+	"ces": "cs", "cze": "cs", "czech": "cs",
+	"chi": "zh", "zho": "zh", "chinese": "zh",
+	"dan": "da", "danish": "da",
+	"deu": "de", "ger": "de", "german": "de",
+	"ell": "el", "gre": "el", "greek": "el",
+	"eng": "en", "english": "en",
+	"est": "et", "estonian": "et",
+	"fin": "fi", "finnish": "fi",
+	"fra": "fr", "fre": "fr", "french": "fr",
+	"heb": "he", "hebrew": "he",
+	"hin": "hi", "hindi": "hi",
+	"hrv": "hr", "croatian": "hr",
+	"hun": "hu", "hungarian": "hu",
+	"ind": "id", "indonesian": "id",
+	"ita": "it", "italian": "it",
+	"jpn": "ja", "japanese": "ja",
+	"kor": "ko", "korean": "ko",
+	"lav": "lv", "latvian": "lv",
+	"lit": "lt", "lithuanian": "lt",
+	"msa": "ms", "may": "ms", "malay": "ms",
+	"nld": "nl", "dut": "nl", "dutch": "nl",
+	"nor": "no", "norwegian": "no",
+	"pol": "pl", "polish": "pl",
+	"por": "pt", "portuguese": "pt",
+	"ron": "ro", "rum": "ro", "romanian": "ro",
+	"rus": "ru", "russian": "ru",
+	"slk": "sk", "slo": "sk", "slovak": "sk",
+	"slv": "sl", "slovenian": "sl",
+	"spa": "es", "spanish": "es",
+	"srp": "sr", "serbian": "sr",
+	"swe": "sv", "swedish": "sv",
+	"tha": "th", "thai": "th",
+	"tur": "tr", "turkish": "tr",
+	"ukr": "uk", "ukrainian": "uk",
+	"vie": "vi", "vietnamese": "vi",
+}
+
+// normalizeLanguage returns the ISO 639-1 code of the specified language
+// code or name, if it is known. Otherwise, the lowercase form of the
+// language is returned. Region suffixes (e.g. en-US, pt_BR) are ignored.
+func normalizeLanguage(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if i := strings.IndexAny(language, "-_"); i > 0 {
+		language = language[:i]
+	}
+
+	if code, ok := languageCodes[language]; ok {
+		return code
+	}
+
+	return language
+}
+
+// isLanguage returns true if the specified token is a known language code
+// or name.
+func isLanguage(token string) bool {
+	token = strings.ToLower(token)
+	if _, ok := languageCodes[token]; ok {
+		return true
+	}
+
+	// Check ISO 639-1 codes.
+	for _, code := range languageCodes {
+		if code == token {
+			return true
+		}
+	}
+
+	return false
+}
+
+// languageRank returns the index of the specified language in the list of
+// preferred languages, or -1 if the language is not preferred.
+func languageRank(language string, preferred []string) int {
+	if language == "" {
+		return -1
+	}
+
+	language = normalizeLanguage(language)
+	for i, pref := range preferred {
+		if normalizeLanguage(pref) == language {
+			return i
+		}
+	}
+
+	return -1
+}
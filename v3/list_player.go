@@ -31,28 +31,48 @@ func (pm PlaybackMode) Validate() error {
 type ListPlayer struct {
 	player *C.libvlc_media_list_player_t
 	list   *MediaList
+	inst   *Instance
 }
 
 // NewListPlayer creates a new list player instance.
 func NewListPlayer() (*ListPlayer, error) {
-	if err := inst.assertInit(); err != nil {
+	return inst.NewListPlayer()
+}
+
+// NewListPlayer creates a new list player which uses the instance.
+func (i *Instance) NewListPlayer() (*ListPlayer, error) {
+	if err := i.assertInit(); err != nil {
 		return nil, err
 	}
 
-	player := C.libvlc_media_list_player_new(inst.handle)
+	player := C.libvlc_media_list_player_new(i.handle)
 	if player == nil {
 		return nil, errOrDefault(getError(), ErrListPlayerCreate)
 	}
 
-	return &ListPlayer{player: player}, nil
+	// Reset the state of the underlying player.
+	if mp := C.libvlc_media_list_player_get_media_player(player); mp != nil {
+		i.players.remove(mp)
+		C.libvlc_media_player_release(mp)
+	}
+
+	return &ListPlayer{player: player, inst: i}, nil
 }
 
-// Release destroys the list player instance.
+// Release destroys the list player instance. The events registered
+// through the event manager of the list player are detached, and the
+// active recording session and track policy of its player are discarded.
 func (lp *ListPlayer) Release() error {
 	if err := lp.assertInit(); err != nil {
 		return nil
 	}
 
+	lp.inst.detachEvents(C.libvlc_media_list_player_event_manager(lp.player))
+	if mp := C.libvlc_media_list_player_get_media_player(lp.player); mp != nil {
+		(&Player{player: mp, inst: lp.inst}).discardState()
+		C.libvlc_media_player_release(mp)
+	}
+
 	C.libvlc_media_list_player_release(lp.player)
 	lp.player = nil
 
@@ -74,7 +94,7 @@ func (lp *ListPlayer) Player() (*Player, error) {
 	// reference count increased by libvlc_media_list_player_get_media_player.
 	C.libvlc_media_player_release(player)
 
-	return &Player{player: player}, nil
+	return &Player{player: player, inst: lp.inst}, nil
 }
 
 // SetPlayer sets the underlying Player instance of the list player.
@@ -259,7 +279,7 @@ func (lp *ListPlayer) EventManager() (*EventManager, error) {
 		return nil, ErrMissingEventManager
 	}
 
-	return newEventManager(manager), nil
+	return newEventManager(lp.inst, manager), nil
 }
 
 func (lp *ListPlayer) assertInit() error {
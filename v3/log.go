@@ -0,0 +1,223 @@
+package vlc
+
+/*
+#cgo LDFLAGS: -lvlc
+#include <vlc/vlc.h>
+#include <stdarg.h>
+#include <stdio.h>
+#include <stdlib.h>
+
+extern void logDispatch(void*, int, char*, char*, char*, unsigned, char*, char*, uintptr_t);
+
+static inline void logCallback(void* data, int level, const libvlc_log_t* ctx, const char* fmt, va_list args) {
+	// Format log message.
+	va_list argsCopy;
+	va_copy(argsCopy, args);
+	int size = vsnprintf(NULL, 0, fmt, argsCopy);
+	va_end(argsCopy);
+	if (size < 0) {
+		return;
+	}
+
+	char* msg = malloc(size + 1);
+	if (msg == NULL) {
+		return;
+	}
+	vsnprintf(msg, size + 1, fmt, args);
+
+	// Retrieve log context.
+	const char *module = NULL, *file = NULL, *name = NULL, *header = NULL;
+	unsigned line = 0;
+	uintptr_t id = 0;
+
+	libvlc_log_get_context(ctx, &module, &file, &line);
+	libvlc_log_get_object(ctx, &name, &header, &id);
+
+	logDispatch(data, level, msg, (char*)module, (char*)file, line, (char*)name, (char*)header, id);
+	free(msg);
+}
+
+static inline void logSet(libvlc_instance_t* instance, void* data) {
+	libvlc_log_set(instance, logCallback, data);
+}
+*/
+import "C"
+import (
+	"fmt"
+	"io"
+	"sync"
+	"unsafe"
+)
+
+// LogLevel represents the severity of a libVLC log message.
+type LogLevel int
+
+// Log levels.
+const (
+	LogDebug   LogLevel = 0
+	LogNotice  LogLevel = 2
+	LogWarning LogLevel = 3
+	LogError   LogLevel = 4
+)
+
+// String returns a string representation of the log level.
+func (l LogLevel) String() string {
+	switch l {
+	case LogDebug:
+		return "debug"
+	case LogNotice:
+		return "notice"
+	case LogWarning:
+		return "warning"
+	case LogError:
+		return "error"
+	}
+
+	return fmt.Sprintf("level(%d)", int(l))
+}
+
+// LogMessage contains a log message emitted by libVLC, along with
+// information about its origin.
+type LogMessage struct {
+	Level   LogLevel // Severity of the message.
+	Message string   // Formatted message.
+
+	// Name of the module which emitted the message (e.g. "avcodec").
+	Module string
+
+	// Type name of the object which emitted the message
+	// (e.g. "decoder", "demux", "input").
+	ObjectType string
+
+	// Object header. Usually empty.
+	ObjectHeader string
+
+	// Temporarily-unique identifier of the object which emitted the message.
+	ObjectID uintptr
+
+	// Source code file and line number which emitted the message. The values
+	// are only available if libVLC was built with debug information.
+	File string
+	Line int
+}
+
+// String returns a string representation of the log message.
+func (lm *LogMessage) String() string {
+	if lm.Module == "" {
+		return fmt.Sprintf("[%s] %s: %s", lm.Level, lm.ObjectType, lm.Message)
+	}
+
+	return fmt.Sprintf("[%s] %s %s: %s", lm.Level, lm.Module, lm.ObjectType, lm.Message)
+}
+
+// LogCallback is used to receive the log messages emitted by libVLC.
+//
+//	NOTE: The callback is invoked from the internal threads of libVLC,
+//	possibly concurrently. It should return as soon as possible and it
+//	must not call libVLC functions.
+type LogCallback func(*LogMessage)
+
+// SetLogger redirects the log messages emitted by libVLC to the specified
+// callback function, instead of the standard error output. Pass in `nil` in
+// order to restore the default logging behavior.
+//
+//	NOTE: The method waits for all pending callback invocations to complete
+//	before returning. Log messages emitted before the logger is set are not
+//	delivered to the callback. The callback is removed when the module
+//	is released.
+func SetLogger(cb LogCallback) error {
+	return inst.SetLogger(cb)
+}
+
+// SetLogWriter redirects the log messages emitted by libVLC, which have a
+// severity level equal to or greater than the specified minimum level, to the
+// provided writer. Each message is written on a separate line. Pass in `nil`
+// as the writer in order to restore the default logging behavior.
+func SetLogWriter(w io.Writer, minLevel LogLevel) error {
+	return inst.SetLogWriter(w, minLevel)
+}
+
+// SetLogger redirects the log messages emitted by the instance to the
+// specified callback function. Pass in `nil` in order to restore the
+// default logging behavior.
+func (i *Instance) SetLogger(cb LogCallback) error {
+	if err := i.assertInit(); err != nil {
+		return err
+	}
+
+	return i.setLogger(cb)
+}
+
+// SetLogWriter redirects the log messages emitted by the instance, which
+// have a severity level equal to or greater than the specified minimum level,
+// to the provided writer. Pass in `nil` as the writer in order to restore the
+// default logging behavior.
+func (i *Instance) SetLogWriter(w io.Writer, minLevel LogLevel) error {
+	if w == nil {
+		return i.SetLogger(nil)
+	}
+
+	var mu sync.Mutex
+	return i.SetLogger(func(msg *LogMessage) {
+		if msg.Level < minLevel {
+			return
+		}
+
+		mu.Lock()
+		fmt.Fprintln(w, msg)
+		mu.Unlock()
+	})
+}
+
+func (i *Instance) setLogger(cb LogCallback) error {
+	i.logMu.Lock()
+	defer i.logMu.Unlock()
+
+	// Remove previous logger.
+	i.unsetLogger()
+	if cb == nil {
+		return nil
+	}
+
+	// Set new logger.
+	i.loggerID = i.objects.add(cb)
+	C.logSet(i.handle, i.loggerID)
+
+	return nil
+}
+
+func (i *Instance) unsetLogger() {
+	if i.loggerID == nil {
+		return
+	}
+
+	// This call waits for pending callback invocations to complete.
+	C.libvlc_log_unset(i.handle)
+
+	i.objects.decRefs(i.loggerID)
+	i.loggerID = nil
+}
+
+//export logDispatch
+func logDispatch(data unsafe.Pointer, level C.int, msg, module, file *C.char,
+	line C.uint, name, header *C.char, id C.uintptr_t) {
+	_, obj, ok := instances.object(data)
+	if !ok {
+		return
+	}
+	cb, ok := obj.(LogCallback)
+	if !ok || cb == nil {
+		return
+	}
+
+	cb(&LogMessage{
+		Level:        LogLevel(level),
+		Message:      C.GoString(msg),
+		Module:       C.GoString(module),
+		ObjectType:   C.GoString(name),
+		ObjectHeader: C.GoString(header),
+		ObjectID:     uintptr(id),
+		File:         C.GoString(file),
+		Line:         int(line),
+	})
+}
@@ -0,0 +1,61 @@
+//go:build go1.21
+// +build go1.21
+
+package vlc
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SetSlogLogger redirects the log messages emitted by libVLC to the specified
+// structured logger. The libVLC log levels are mapped to their slog
+// counterparts, with LogNotice being mapped to slog.LevelInfo. The origin of
+// each message is recorded as a set of attributes. Pass in `nil` in order to
+// restore the default logging behavior.
+func SetSlogLogger(l *slog.Logger) error {
+	return inst.SetSlogLogger(l)
+}
+
+// SetSlogLogger redirects the log messages emitted by the instance to the
+// specified structured logger. Pass in `nil` in order to restore the default
+// logging behavior.
+func (i *Instance) SetSlogLogger(l *slog.Logger) error {
+	if l == nil {
+		return i.SetLogger(nil)
+	}
+
+	return i.SetLogger(func(msg *LogMessage) {
+		ctx, level := context.Background(), slogLevel(msg.Level)
+		if !l.Enabled(ctx, level) {
+			return
+		}
+
+		attrs := []slog.Attr{
+			slog.String("module", msg.Module),
+			slog.String("object_type", msg.ObjectType),
+			slog.Uint64("object_id", uint64(msg.ObjectID)),
+		}
+		if msg.File != "" {
+			attrs = append(attrs,
+				slog.String("file", msg.File),
+				slog.Int("line", msg.Line),
+			)
+		}
+
+		l.LogAttrs(ctx, level, msg.Message, attrs...)
+	})
+}
+
+func slogLevel(level LogLevel) slog.Level {
+	switch {
+	case level >= LogError:
+		return slog.LevelError
+	case level >= LogWarning:
+		return slog.LevelWarn
+	case level >= LogNotice:
+		return slog.LevelInfo
+	}
+
+	return slog.LevelDebug
+}
@@ -223,31 +223,64 @@ type mediaData struct {
 // Media is an abstract representation of a playable media file.
 type Media struct {
 	media *C.libvlc_media_t
+	inst  *Instance
 }
 
 // NewMediaFromPath creates a new media instance based on the media
-// located at the specified path.
+// located at the specified path. The subtitle files located next to the
+// media are attached if the sidecar discovery is enabled. See the
+// SetSidecarDiscovery function.
 func NewMediaFromPath(path string) (*Media, error) {
-	return newMedia(path, true)
+	return inst.NewMediaFromPath(path)
 }
 
 // NewMediaFromURL creates a new media instance based on the media
 // located at the specified URL.
 func NewMediaFromURL(url string) (*Media, error) {
-	return newMedia(url, false)
+	return inst.NewMediaFromURL(url)
 }
 
 // NewMediaFromReadSeeker creates a new media instance based on the
 // provided read seeker.
 func NewMediaFromReadSeeker(r io.ReadSeeker) (*Media, error) {
-	if err := inst.assertInit(); err != nil {
+	return inst.NewMediaFromReadSeeker(r)
+}
+
+// NewMediaFromScreen creates a media instance from the current computer
+// screen, using the specified options.
+//
+//	NOTE: This functionality requires the VLC screen module to be installed.
+//	See installation instructions at https://github.com/adrg/libvlc-go/wiki.
+//	See https://wiki.videolan.org/Documentation:Modules/screen.
+func NewMediaFromScreen(opts *MediaScreenOptions) (*Media, error) {
+	return inst.NewMediaFromScreen(opts)
+}
+
+// NewMediaFromPath creates a new media instance, which uses the instance,
+// based on the media located at the specified path. The subtitle files
+// located next to the media are attached if the sidecar discovery of the
+// instance is enabled. See the Instance.SetSidecarDiscovery method.
+func (i *Instance) NewMediaFromPath(path string) (*Media, error) {
+	return i.newMedia(path, true)
+}
+
+// NewMediaFromURL creates a new media instance, which uses the instance,
+// based on the media located at the specified URL.
+func (i *Instance) NewMediaFromURL(url string) (*Media, error) {
+	return i.newMedia(url, false)
+}
+
+// NewMediaFromReadSeeker creates a new media instance, which uses the
+// instance, based on the provided read seeker.
+func (i *Instance) NewMediaFromReadSeeker(r io.ReadSeeker) (*Media, error) {
+	if err := i.assertInit(); err != nil {
 		return nil, err
 	}
 
 	// Create media.
-	readerID := inst.objects.add(r)
+	readerID := i.objects.add(r)
 	cMedia := C.libvlc_media_new_callbacks(
-		inst.handle,
+		i.handle,
 		C.media_open_cb_wrapper(),
 		C.media_read_cb_wrapper(),
 		C.media_seek_cb_wrapper(),
@@ -255,25 +288,21 @@ func NewMediaFromReadSeeker(r io.ReadSeeker) (*Media, error) {
 		readerID,
 	)
 	if cMedia == nil {
-		inst.objects.decRefs(readerID)
+		i.objects.decRefs(readerID)
 		return nil, errOrDefault(getError(), ErrMediaCreate)
 	}
 
 	// Set user data.
-	m := &Media{media: cMedia}
+	m := &Media{media: cMedia, inst: i}
 	m.setUserData(&mediaData{readerID: readerID})
 
 	return m, nil
 }
 
-// NewMediaFromScreen creates a media instance from the current computer
-// screen, using the specified options.
-//
-//	NOTE: This functionality requires the VLC screen module to be installed.
-//	See installation instructions at https://github.com/adrg/libvlc-go/wiki.
-//	See https://wiki.videolan.org/Documentation:Modules/screen.
-func NewMediaFromScreen(opts *MediaScreenOptions) (*Media, error) {
-	media, err := newMedia("screen://", false)
+// NewMediaFromScreen creates a media instance, which uses the instance,
+// from the current computer screen, using the specified options.
+func (i *Instance) NewMediaFromScreen(opts *MediaScreenOptions) (*Media, error) {
+	media, err := i.newMedia("screen://", false)
 	if err != nil {
 		return nil, err
 	}
@@ -314,11 +343,14 @@ func NewMediaFromScreen(opts *MediaScreenOptions) (*Media, error) {
 	return media, nil
 }
 
-// Release destroys the media instance.
+// Release destroys the media instance. The events registered through
+// the event manager of the media are detached.
 func (m *Media) Release() error {
 	if err := m.assertInit(); err != nil {
 		return nil
 	}
+	m.inst.detachEvents(C.libvlc_media_event_manager(m.media))
+	m.inst.removeMediaCredentials(unsafe.Pointer(m.media))
 
 	m.release()
 	return nil
@@ -340,11 +372,11 @@ func (m *Media) Duplicate() (*Media, error) {
 	}
 
 	// Duplicate user data.
-	dup := &Media{media: cMedia}
+	dup := &Media{media: cMedia, inst: m.inst}
 	if _, data := m.getUserData(); data != nil {
 		dupData := *data
 		dup.setUserData(&dupData)
-		inst.objects.incRefs(dupData.readerID)
+		m.inst.objects.incRefs(dupData.readerID)
 	}
 
 	return dup, nil
@@ -590,7 +622,7 @@ func (m *Media) SubItems() (*MediaList, error) {
 		return nil, errOrDefault(getError(), ErrMediaListNotFound)
 	}
 
-	return &MediaList{list: subitems}, nil
+	return &MediaList{list: subitems, inst: m.inst}, nil
 }
 
 // Tracks returns the tracks (audio, video, subtitle) of the current media.
@@ -678,7 +710,7 @@ func (m *Media) EventManager() (*EventManager, error) {
 		return nil, ErrMissingEventManager
 	}
 
-	return newEventManager(manager), nil
+	return newEventManager(m.inst, manager), nil
 }
 
 func (m *Media) addOption(option string) error {
@@ -694,12 +726,12 @@ func (m *Media) addOption(option string) error {
 }
 
 func (m *Media) getUserData() (objectID, *mediaData) {
-	if err := inst.assertInit(); err != nil {
+	if err := m.inst.assertInit(); err != nil {
 		return nil, nil
 	}
 	id := C.libvlc_media_get_user_data(m.media)
 
-	obj, ok := inst.objects.get(id)
+	obj, ok := m.inst.objects.get(id)
 	if !ok {
 		return nil, nil
 	}
@@ -713,7 +745,7 @@ func (m *Media) getUserData() (objectID, *mediaData) {
 }
 
 func (m *Media) setUserData(data *mediaData) objectID {
-	id := inst.objects.add(data)
+	id := m.inst.objects.add(data)
 	C.libvlc_media_set_user_data(m.media, id)
 	return id
 }
@@ -724,8 +756,8 @@ func (m *Media) deleteUserData() {
 		return
 	}
 
-	inst.objects.decRefs(data.readerID)
-	inst.objects.decRefs(id)
+	m.inst.objects.decRefs(data.readerID)
+	m.inst.objects.decRefs(id)
 }
 
 func (m *Media) release() {
@@ -745,8 +777,8 @@ func (m *Media) assertInit() error {
 	return nil
 }
 
-func newMedia(path string, local bool) (*Media, error) {
-	if err := inst.assertInit(); err != nil {
+func (i *Instance) newMedia(path string, local bool) (*Media, error) {
+	if err := i.assertInit(); err != nil {
 		return nil, err
 	}
 
@@ -759,24 +791,28 @@ func newMedia(path string, local bool) (*Media, error) {
 			return nil, err
 		}
 
-		media = C.libvlc_media_new_path(inst.handle, cPath)
+		media = C.libvlc_media_new_path(i.handle, cPath)
 	} else {
-		media = C.libvlc_media_new_location(inst.handle, cPath)
+		media = C.libvlc_media_new_location(i.handle, cPath)
 	}
 
 	if media == nil {
 		return nil, errOrDefault(getError(), ErrMediaCreate)
 	}
+	m := &Media{media: media, inst: i}
 
-	return &Media{media: media}, nil
+	// Attach sidecar subtitles, if the automatic discovery is enabled. The
+	// discovery is best-effort, so its errors (e.g. the media directory
+	// cannot be read) do not prevent the media from being created.
+	if local {
+		i.attachSidecarSubtitles(m)
+	}
+
+	return m, nil
 }
 
 func getMediaReadSeeker(id objectID) (io.ReadSeeker, error) {
-	if err := inst.assertInit(); err != nil {
-		return nil, err
-	}
-
-	obj, ok := inst.objects.get(id)
+	_, obj, ok := instances.object(id)
 	if !ok {
 		return nil, ErrMediaNotInitialized
 	}
@@ -50,14 +50,21 @@ type MediaDiscovererDescriptor struct {
 // ListMediaDiscoverers returns a list of descriptors identifying the
 // available media discovery services of the specified category.
 func ListMediaDiscoverers(category MediaDiscoveryCategory) ([]*MediaDiscovererDescriptor, error) {
-	if err := inst.assertInit(); err != nil {
+	return inst.ListMediaDiscoverers(category)
+}
+
+// ListMediaDiscoverers returns a list of descriptors identifying the
+// media discovery services of the specified category, available to
+// the instance.
+func (i *Instance) ListMediaDiscoverers(category MediaDiscoveryCategory) ([]*MediaDiscovererDescriptor, error) {
+	if err := i.assertInit(); err != nil {
 		return nil, err
 	}
 
 	// Get media discoverer descriptors.
 	var cDescriptors **C.libvlc_media_discoverer_description_t
 
-	count := int(C.libvlc_media_discoverer_list_get(inst.handle, C.libvlc_media_discoverer_category_t(category), &cDescriptors))
+	count := int(C.libvlc_media_discoverer_list_get(i.handle, C.libvlc_media_discoverer_category_t(category), &cDescriptors))
 	if count <= 0 || cDescriptors == nil {
 		return nil, nil
 	}
@@ -95,6 +102,7 @@ func ListMediaDiscoverers(category MediaDiscoveryCategory) ([]*MediaDiscovererDe
 type MediaDiscoverer struct {
 	discoverer *C.libvlc_media_discoverer_t
 	stopFunc   func()
+	inst       *Instance
 }
 
 // NewMediaDiscoverer instantiates the media discovery service identified
@@ -104,20 +112,27 @@ type MediaDiscoverer struct {
 //	NOTE: Call the Release method on the discovery service instance in
 //	order to free the allocated resources.
 func NewMediaDiscoverer(name string) (*MediaDiscoverer, error) {
-	if err := inst.assertInit(); err != nil {
+	return inst.NewMediaDiscoverer(name)
+}
+
+// NewMediaDiscoverer instantiates the media discovery service identified
+// by the specified name, using the instance.
+func (i *Instance) NewMediaDiscoverer(name string) (*MediaDiscoverer, error) {
+	if err := i.assertInit(); err != nil {
 		return nil, err
 	}
 
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 
-	discoverer := C.libvlc_media_discoverer_new(inst.handle, cName)
+	discoverer := C.libvlc_media_discoverer_new(i.handle, cName)
 	if discoverer == nil {
 		return nil, errOrDefault(getError(), ErrMediaDiscovererCreate)
 	}
 
 	return &MediaDiscoverer{
 		discoverer: discoverer,
+		inst:       i,
 	}, nil
 }
 
@@ -179,7 +194,7 @@ func (md *MediaDiscoverer) Start(cb MediaDiscoveryCallback) error {
 		if cMedia == nil {
 			return
 		}
-		media := &Media{media: cMedia}
+		media := &Media{media: cMedia, inst: md.inst}
 
 		// Parse event media index.
 		cIndex := (*C.int)(unsafe.Pointer(uintptr(unsafe.Pointer(&event.u[0])) +
@@ -272,7 +287,7 @@ func (md *MediaDiscoverer) MediaList() (*MediaList, error) {
 	// the reference count increased by libvlc_media_discoverer_media_list.
 	C.libvlc_media_list_release(ml)
 
-	return &MediaList{list: ml}, nil
+	return &MediaList{list: ml, inst: md.inst}, nil
 }
 
 func (md *MediaDiscoverer) stop() {
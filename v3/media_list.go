@@ -8,28 +8,37 @@ import "io"
 // MediaList represents a collection of media files.
 type MediaList struct {
 	list *C.libvlc_media_list_t
+	inst *Instance
 }
 
 // NewMediaList creates an empty media list.
 func NewMediaList() (*MediaList, error) {
-	if err := inst.assertInit(); err != nil {
+	return inst.NewMediaList()
+}
+
+// NewMediaList creates an empty media list using the instance.
+func (i *Instance) NewMediaList() (*MediaList, error) {
+	if err := i.assertInit(); err != nil {
 		return nil, err
 	}
 
 	var list *C.libvlc_media_list_t
-	if list = C.libvlc_media_list_new(inst.handle); list == nil {
+	if list = C.libvlc_media_list_new(i.handle); list == nil {
 		return nil, errOrDefault(getError(), ErrMediaListCreate)
 	}
 
-	return &MediaList{list: list}, nil
+	return &MediaList{list: list, inst: i}, nil
 }
 
-// Release destroys the media list instance.
+// Release destroys the media list instance. The events registered
+// through the event manager of the media list are detached.
 func (ml *MediaList) Release() error {
 	if err := ml.assertInit(); err != nil {
 		return nil
 	}
 
+	ml.inst.detachEvents(C.libvlc_media_list_event_manager(ml.list))
+
 	C.libvlc_media_list_release(ml.list)
 	ml.list = nil
 
@@ -68,7 +77,11 @@ func (ml *MediaList) AddMedia(m *Media) error {
 // AddMediaFromPath loads the media file at the specified path and adds it at
 // the end of the media list.
 func (ml *MediaList) AddMediaFromPath(path string) error {
-	media, err := NewMediaFromPath(path)
+	if err := ml.assertInit(); err != nil {
+		return err
+	}
+
+	media, err := ml.inst.NewMediaFromPath(path)
 	if err != nil {
 		return err
 	}
@@ -85,7 +98,11 @@ func (ml *MediaList) AddMediaFromPath(path string) error {
 // AddMediaFromURL loads the media file at the specified URL and adds it at
 // the end of the the media list.
 func (ml *MediaList) AddMediaFromURL(url string) error {
-	media, err := NewMediaFromURL(url)
+	if err := ml.assertInit(); err != nil {
+		return err
+	}
+
+	media, err := ml.inst.NewMediaFromURL(url)
 	if err != nil {
 		return err
 	}
@@ -101,7 +118,11 @@ func (ml *MediaList) AddMediaFromURL(url string) error {
 // AddMediaFromReadSeeker loads the media from the provided read
 // seeker and adds it at the end of the media list.
 func (ml *MediaList) AddMediaFromReadSeeker(r io.ReadSeeker) error {
-	media, err := NewMediaFromReadSeeker(r)
+	if err := ml.assertInit(); err != nil {
+		return err
+	}
+
+	media, err := ml.inst.NewMediaFromReadSeeker(r)
 	if err != nil {
 		return err
 	}
@@ -147,7 +168,11 @@ func (ml *MediaList) InsertMedia(m *Media, index uint) error {
 // InsertMediaFromPath loads the media file at the provided path and inserts
 // it in the list, at the specified index.
 func (ml *MediaList) InsertMediaFromPath(path string, index uint) error {
-	media, err := NewMediaFromPath(path)
+	if err := ml.assertInit(); err != nil {
+		return err
+	}
+
+	media, err := ml.inst.NewMediaFromPath(path)
 	if err != nil {
 		return err
 	}
@@ -164,7 +189,11 @@ func (ml *MediaList) InsertMediaFromPath(path string, index uint) error {
 // InsertMediaFromURL loads the media file at the provided URL and inserts
 // it in the list, at the specified index.
 func (ml *MediaList) InsertMediaFromURL(url string, index uint) error {
-	media, err := NewMediaFromURL(url)
+	if err := ml.assertInit(); err != nil {
+		return err
+	}
+
+	media, err := ml.inst.NewMediaFromURL(url)
 	if err != nil {
 		return err
 	}
@@ -181,7 +210,11 @@ func (ml *MediaList) InsertMediaFromURL(url string, index uint) error {
 // InsertMediaFromReadSeeker loads the media from the provided read
 // seeker and inserts it in the list, at the specified index.
 func (ml *MediaList) InsertMediaFromReadSeeker(r io.ReadSeeker, index uint) error {
-	media, err := NewMediaFromReadSeeker(r)
+	if err := ml.assertInit(); err != nil {
+		return err
+	}
+
+	media, err := ml.inst.NewMediaFromReadSeeker(r)
 	if err != nil {
 		return err
 	}
@@ -239,7 +272,7 @@ func (ml *MediaList) MediaAtIndex(index uint) (*Media, error) {
 	// the reference count increased by libvlc_media_list_item_at_index.
 	C.libvlc_media_release(media)
 
-	return &Media{media}, nil
+	return &Media{media: media, inst: ml.inst}, nil
 }
 
 // IndexOfMedia returns the index of the specified media item in the list.
@@ -305,7 +338,7 @@ func (ml *MediaList) AssociatedMedia() (*Media, error) {
 	// the reference count increased by libvlc_media_list_media.
 	C.libvlc_media_release(media)
 
-	return &Media{media: media}, nil
+	return &Media{media: media, inst: ml.inst}, nil
 }
 
 // AssociateMedia associates the specified media with the media list instance.
@@ -355,7 +388,7 @@ func (ml *MediaList) EventManager() (*EventManager, error) {
 		return nil, ErrMissingEventManager
 	}
 
-	return newEventManager(manager), nil
+	return newEventManager(ml.inst, manager), nil
 }
 
 func (ml *MediaList) assertInit() error {
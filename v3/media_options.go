@@ -0,0 +1,212 @@
+package vlc
+
+// #cgo LDFLAGS: -lvlc
+// #include <vlc/vlc.h>
+// #include <stdlib.h>
+import "C"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+// MediaOptionFlag represents a flag which determines how a media option
+// is applied.
+type MediaOptionFlag uint
+
+// Media option flags.
+const (
+	// The option is trusted, which allows options that are considered
+	// unsafe (e.g. options which access files) to be applied.
+	MediaOptionTrusted MediaOptionFlag = 0x2
+
+	// The option replaces the previous value of the option, instead of
+	// being added to its list of values.
+	MediaOptionUnique MediaOptionFlag = 0x100
+)
+
+// AddOptionsWithFlags adds the specified options to the media, using the
+// specified flags (e.g. vlc.MediaOptionTrusted|vlc.MediaOptionUnique).
+func (m *Media) AddOptionsWithFlags(flags MediaOptionFlag, options ...string) error {
+	if err := m.assertInit(); err != nil {
+		return err
+	}
+
+	for _, option := range options {
+		if option == "" {
+			continue
+		}
+
+		cOption := C.CString(option)
+		C.libvlc_media_add_option_flag(m.media, cOption, C.uint(flags))
+		C.free(unsafe.Pointer(cOption))
+
+		if err := getError(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// maxMediaCaching is the maximum caching value accepted by libVLC.
+const maxMediaCaching = time.Minute
+
+// MediaOptions contains commonly used media options. The zero values of
+// the fields are not applied, so the defaults of libVLC are used.
+type MediaOptions struct {
+	// Playback interval of the media. A stop time of 0 plays the media
+	// until its end.
+	StartTime time.Duration
+	StopTime  time.Duration
+
+	// Number of times the media is repeated, in the [0, 65535] interval.
+	InputRepeat uint
+
+	// Caching values for network, file and live capture media. The values
+	// are rounded to milliseconds and must not exceed one minute.
+	NetworkCaching time.Duration
+	FileCaching    time.Duration
+	LiveCaching    time.Duration
+
+	// HTTP user agent and referrer used to access the media.
+	HTTPUserAgent string
+	HTTPReferrer  string
+
+	// Path of an external subtitle file, the character encoding of the
+	// subtitles (e.g. UTF-8, Windows-1252) and the delay applied to the
+	// subtitles of the external file. The delay is rounded to tenths
+	// of a second.
+	SubtitleFile    string
+	SubtitleCharset string
+	SubtitleDelay   time.Duration
+
+	// Preferred audio and subtitle track languages (e.g. en, fre).
+	AudioLanguage    string
+	SubtitleLanguage string
+
+	// Disable the audio or the video of the media.
+	NoAudio bool
+	NoVideo bool
+}
+
+// Validate returns an error if the media options are not valid.
+func (mo *MediaOptions) Validate() error {
+	if mo == nil {
+		return ErrInvalidMediaOptions
+	}
+
+	if mo.StartTime < 0 || mo.StopTime < 0 {
+		return fmt.Errorf("%w: negative start or stop time", ErrInvalidMediaOptions)
+	}
+	if mo.StopTime > 0 && mo.StopTime <= mo.StartTime {
+		return fmt.Errorf("%w: stop time must be after start time", ErrInvalidMediaOptions)
+	}
+	if mo.InputRepeat > 65535 {
+		return fmt.Errorf("%w: invalid input repeat %d", ErrInvalidMediaOptions, mo.InputRepeat)
+	}
+
+	caching := map[string]time.Duration{
+		"network caching": mo.NetworkCaching,
+		"file caching":    mo.FileCaching,
+		"live caching":    mo.LiveCaching,
+	}
+	for name, value := range caching {
+		if value < 0 || value > maxMediaCaching {
+			return fmt.Errorf("%w: invalid %s %s", ErrInvalidMediaOptions, name, value)
+		}
+	}
+
+	values := map[string]string{
+		"HTTP user agent":   mo.HTTPUserAgent,
+		"HTTP referrer":     mo.HTTPReferrer,
+		"subtitle file":     mo.SubtitleFile,
+		"subtitle charset":  mo.SubtitleCharset,
+		"audio language":    mo.AudioLanguage,
+		"subtitle language": mo.SubtitleLanguage,
+	}
+	for name, value := range values {
+		if strings.ContainsAny(value, "\r\n") {
+			return fmt.Errorf("%w: invalid %s %q", ErrInvalidMediaOptions, name, value)
+		}
+	}
+	if mo.NoAudio && mo.NoVideo {
+		return fmt.Errorf("%w: both audio and video are disabled", ErrInvalidMediaOptions)
+	}
+
+	return nil
+}
+
+// Options returns the libVLC representation of the media options
+// (e.g. `:network-caching=300`), without validating them. The result can
+// be used for logging purposes.
+func (mo *MediaOptions) Options() []string {
+	if mo == nil {
+		return nil
+	}
+
+	var opts []string
+	add := func(name, value string) {
+		if value != "" {
+			opts = append(opts, ":"+name+"="+value)
+		}
+	}
+	addDuration := func(name string, value, unit time.Duration) {
+		if value != 0 {
+			add(name, strconv.FormatInt(int64(value.Round(unit)/unit), 10))
+		}
+	}
+	addFlag := func(name string, value bool) {
+		if value {
+			opts = append(opts, ":"+name)
+		}
+	}
+
+	if mo.StartTime > 0 {
+		add("start-time", formatSeconds(mo.StartTime))
+	}
+	if mo.StopTime > 0 {
+		add("stop-time", formatSeconds(mo.StopTime))
+	}
+	if mo.InputRepeat > 0 {
+		add("input-repeat", strconv.FormatUint(uint64(mo.InputRepeat), 10))
+	}
+	addDuration("network-caching", mo.NetworkCaching, time.Millisecond)
+	addDuration("file-caching", mo.FileCaching, time.Millisecond)
+	addDuration("live-caching", mo.LiveCaching, time.Millisecond)
+	add("http-user-agent", mo.HTTPUserAgent)
+	add("http-referrer", mo.HTTPReferrer)
+	add("sub-file", mo.SubtitleFile)
+	add("subsdec-encoding", mo.SubtitleCharset)
+	addDuration("sub-delay", mo.SubtitleDelay, 100*time.Millisecond)
+	add("audio-language", mo.AudioLanguage)
+	add("sub-language", mo.SubtitleLanguage)
+	addFlag("no-audio", mo.NoAudio)
+	addFlag("no-video", mo.NoVideo)
+
+	return opts
+}
+
+// String returns the libVLC representation of the media options, as
+// a space separated list.
+func (mo *MediaOptions) String() string {
+	return strings.Join(mo.Options(), " ")
+}
+
+// ApplyOptions validates the specified media options and adds them to the
+// media. The options are added as trusted and unique, so they replace the
+// values of the same options added previously.
+//
+//	NOTE: The options are used the next time the media is played.
+func (m *Media) ApplyOptions(opts *MediaOptions) error {
+	if err := m.assertInit(); err != nil {
+		return err
+	}
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	return m.AddOptionsWithFlags(MediaOptionTrusted|MediaOptionUnique, opts.Options()...)
+}
@@ -0,0 +1,146 @@
+package vlc
+
+// #cgo LDFLAGS: -lvlc
+// #include <vlc/vlc.h>
+// #include <stdlib.h>
+import "C"
+import (
+	"unsafe"
+)
+
+// MediaSlaveType represents the type of a media slave.
+type MediaSlaveType uint
+
+// Media slave types.
+const (
+	MediaSlaveSubtitle MediaSlaveType = iota
+	MediaSlaveAudio
+)
+
+// Validate returns an error if the media slave type is not valid.
+func (mst MediaSlaveType) Validate() error {
+	if mst > MediaSlaveAudio {
+		return ErrInvalidMediaSlave
+	}
+
+	return nil
+}
+
+// MaxMediaSlavePriority is the highest priority of a media slave, which is
+// also the priority of the slaves added by users.
+const MaxMediaSlavePriority = 4
+
+// MediaSlave represents an external file (e.g. a subtitle file or an audio
+// track), which is loaded alongside a media.
+type MediaSlave struct {
+	// Type of the slave.
+	Type MediaSlaveType
+
+	// URI of the slave (e.g. file:///home/user/movie.srt).
+	URI string
+
+	// Priority of the slave, in the [0, MaxMediaSlavePriority] interval.
+	Priority uint
+}
+
+// AddSlave adds an external file (e.g. a subtitle file or an audio track)
+// to the media, with the specified priority. The slave is loaded when the
+// media is played. Local files must be specified using file:// URIs.
+// The priority must be in the [0, MaxMediaSlavePriority] interval.
+//
+//	NOTE: The slaves must be added before the media is played.
+func (m *Media) AddSlave(slaveType MediaSlaveType, uri string, priority uint) error {
+	if err := m.assertInit(); err != nil {
+		return err
+	}
+	if err := slaveType.Validate(); err != nil {
+		return err
+	}
+	if uri == "" || priority > MaxMediaSlavePriority {
+		return ErrInvalidMediaSlave
+	}
+
+	cURI := C.CString(uri)
+	defer C.free(unsafe.Pointer(cURI))
+
+	if C.libvlc_media_slaves_add(m.media, C.libvlc_media_slave_type_t(slaveType), C.uint(priority), cURI) != 0 {
+		return errOrDefault(getError(), ErrMediaSlaveAdd)
+	}
+
+	return nil
+}
+
+// Slaves returns the slaves of the media. The returned list contains the
+// slaves added using the AddSlave method and, once the media is parsed,
+// the slaves detected automatically by libVLC.
+func (m *Media) Slaves() ([]*MediaSlave, error) {
+	if err := m.assertInit(); err != nil {
+		return nil, err
+	}
+
+	var cSlaves **C.libvlc_media_slave_t
+
+	count := int(C.libvlc_media_slaves_get(m.media, &cSlaves))
+	if count <= 0 || cSlaves == nil {
+		return nil, nil
+	}
+	defer C.libvlc_media_slaves_release(cSlaves, C.uint(count))
+
+	slaves := make([]*MediaSlave, 0, count)
+	for i := 0; i < count; i++ {
+		// Get current slave pointer.
+		cSlave := *(**C.libvlc_media_slave_t)(unsafe.Pointer(uintptr(unsafe.Pointer(cSlaves)) +
+			uintptr(i)*unsafe.Sizeof(*cSlaves)))
+		if cSlave == nil {
+			continue
+		}
+
+		slaves = append(slaves, &MediaSlave{
+			Type:     MediaSlaveType(cSlave.i_type),
+			URI:      C.GoString(cSlave.psz_uri),
+			Priority: uint(cSlave.i_priority),
+		})
+	}
+
+	return slaves, nil
+}
+
+// ClearSlaves removes all the slaves of the media, including the ones
+// detected automatically by libVLC.
+func (m *Media) ClearSlaves() error {
+	if err := m.assertInit(); err != nil {
+		return err
+	}
+
+	C.libvlc_media_slaves_clear(m.media)
+	return nil
+}
+
+// AddSlave adds an external file (e.g. a subtitle file or an audio track)
+// to the current media of the player. If selectTrack is true, the track of the
+// slave is selected once it is loaded. Local files must be specified using
+// file:// URIs.
+//
+//	NOTE: Unlike Media.AddSlave, the method can be used while the media is
+//	playing. The slave is only added to the current playback, so it is not
+//	returned by the Media.Slaves method.
+func (p *Player) AddSlave(slaveType MediaSlaveType, uri string, selectTrack bool) error {
+	if err := p.assertInit(); err != nil {
+		return err
+	}
+	if err := slaveType.Validate(); err != nil {
+		return err
+	}
+	if uri == "" {
+		return ErrInvalidMediaSlave
+	}
+
+	cURI := C.CString(uri)
+	defer C.free(unsafe.Pointer(cURI))
+
+	if C.libvlc_media_player_add_slave(p.player, C.libvlc_media_slave_type_t(slaveType), cURI, C.bool(selectTrack)) != 0 {
+		return errOrDefault(getError(), ErrMediaSlaveAdd)
+	}
+
+	return nil
+}
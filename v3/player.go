@@ -106,30 +106,50 @@ const (
 // For playing media lists (playlists) use ListPlayer instead.
 type Player struct {
 	player *C.libvlc_media_player_t
+	inst   *Instance
+
+	videoSinkID objectID
+	audioSinkID objectID
 }
 
 // NewPlayer creates an instance of a single-media player.
 func NewPlayer() (*Player, error) {
-	if err := inst.assertInit(); err != nil {
+	return inst.NewPlayer()
+}
+
+// NewPlayer creates a single-media player which uses the instance.
+func (i *Instance) NewPlayer() (*Player, error) {
+	if err := i.assertInit(); err != nil {
 		return nil, err
 	}
 
-	player := C.libvlc_media_player_new(inst.handle)
+	player := C.libvlc_media_player_new(i.handle)
 	if player == nil {
 		return nil, errOrDefault(getError(), ErrPlayerCreate)
 	}
+	i.players.remove(player)
 
-	return &Player{player: player}, nil
+	return &Player{player: player, inst: i}, nil
 }
 
-// Release destroys the media player instance.
+// Release destroys the media player instance. The events registered
+// through the event manager of the player are detached, and the active
+// recording session and track policy of the player are discarded.
 func (p *Player) Release() error {
 	if err := p.assertInit(); err != nil {
 		return nil
 	}
+	p.discardState()
+	p.inst.detachEvents(C.libvlc_media_player_event_manager(p.player))
 
 	C.libvlc_media_player_release(p.player)
 	p.player = nil
+
+	// Release video and audio sink contexts.
+	p.inst.objects.decRefs(p.videoSinkID)
+	p.inst.objects.decRefs(p.audioSinkID)
+	p.videoSinkID, p.audioSinkID = nil, nil
+
 	return nil
 }
 
@@ -374,7 +394,7 @@ func (p *Player) Media() (*Media, error) {
 	// the reference count increased by libvlc_media_player_get_media.
 	C.libvlc_media_release(media)
 
-	return &Media{media}, nil
+	return &Media{media: media, inst: p.inst}, nil
 }
 
 // SetMedia sets the provided media as the current media of the player.
@@ -383,7 +403,9 @@ func (p *Player) SetMedia(m *Media) error {
 }
 
 // LoadMediaFromPath loads the media located at the specified path and sets
-// it as the current media of the player.
+// it as the current media of the player. The subtitle files located next
+// to the media are attached if the sidecar discovery is enabled. See the
+// SetSidecarDiscovery function.
 func (p *Player) LoadMediaFromPath(path string) (*Media, error) {
 	return p.loadMedia(path, true)
 }
@@ -397,7 +419,11 @@ func (p *Player) LoadMediaFromURL(url string) (*Media, error) {
 // LoadMediaFromReadSeeker loads the media from the provided read seeker
 // and sets it as the current media of the player.
 func (p *Player) LoadMediaFromReadSeeker(r io.ReadSeeker) (*Media, error) {
-	m, err := NewMediaFromReadSeeker(r)
+	if err := p.assertInit(); err != nil {
+		return nil, err
+	}
+
+	m, err := p.inst.NewMediaFromReadSeeker(r)
 	if err != nil {
 		return nil, err
 	}
@@ -1537,11 +1563,15 @@ func (p *Player) EventManager() (*EventManager, error) {
 		return nil, ErrMissingEventManager
 	}
 
-	return newEventManager(manager), nil
+	return newEventManager(p.inst, manager), nil
 }
 
 func (p *Player) loadMedia(path string, local bool) (*Media, error) {
-	m, err := newMedia(path, local)
+	if err := p.assertInit(); err != nil {
+		return nil, err
+	}
+
+	m, err := p.inst.newMedia(path, local)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,111 @@
+package vlc
+
+// #cgo LDFLAGS: -lvlc
+// #include <vlc/vlc.h>
+import "C"
+import (
+	"sync"
+)
+
+// playerState contains the state maintained by the bindings for a libVLC
+// player (e.g. the active recording session). The state is associated with
+// the libVLC player, not with the Player wrapper, so that it is shared by
+// all the wrappers of the same player (e.g. the player returned by the
+// ListPlayer.Player method).
+type playerState struct {
+	sync.Mutex
+
+	recording   *playerRecording
+	trackPolicy *trackPolicyState
+
+	// Set while a recording session is being started or stopped.
+	recordingBusy bool
+
+	// Serializes the track selections performed by track policies.
+	tracksMu sync.Mutex
+}
+
+type playerStateRegistry struct {
+	sync.Mutex
+
+	states map[*C.libvlc_media_player_t]*playerState
+}
+
+func newPlayerStateRegistry() *playerStateRegistry {
+	return &playerStateRegistry{
+		states: map[*C.libvlc_media_player_t]*playerState{},
+	}
+}
+
+// get returns the state of the specified player, creating it if needed.
+func (psr *playerStateRegistry) get(player *C.libvlc_media_player_t) *playerState {
+	psr.Lock()
+	defer psr.Unlock()
+
+	state, ok := psr.states[player]
+	if !ok {
+		state = &playerState{}
+		psr.states[player] = state
+	}
+
+	return state
+}
+
+// remove removes the state of the specified player. Called when the player
+// is released, or when a new player is created, as libVLC can reuse the
+// addresses of released players.
+func (psr *playerStateRegistry) remove(player *C.libvlc_media_player_t) {
+	psr.Lock()
+	delete(psr.states, player)
+	psr.Unlock()
+}
+
+// state returns the state of the libVLC player wrapped by the player.
+func (p *Player) state() *playerState {
+	return p.inst.players.get(p.player)
+}
+
+// discardState discards the active recording session and the track policy
+// of the player, and removes its state.
+func (p *Player) discardState() {
+	p.discardRecording()
+	p.discardTrackPolicy()
+	p.inst.players.remove(p.player)
+}
+
+// reserveRecording reserves the recording session of the player, while
+// the recording is being started.
+func (ps *playerState) reserveRecording() error {
+	ps.Lock()
+	defer ps.Unlock()
+
+	if ps.recording != nil || ps.recordingBusy {
+		return ErrRecordingActive
+	}
+	ps.recordingBusy = true
+
+	return nil
+}
+
+// takeRecording removes the active recording session of the player, which
+// is being stopped.
+func (ps *playerState) takeRecording() (*playerRecording, error) {
+	ps.Lock()
+	defer ps.Unlock()
+
+	if ps.recording == nil || ps.recordingBusy {
+		return nil, ErrRecordingNotActive
+	}
+	rec := ps.recording
+	ps.recording, ps.recordingBusy = nil, true
+
+	return rec, nil
+}
+
+// setRecording sets the active recording session of the player, once the
+// recording is started or stopped. Pass in nil if no session is active.
+func (ps *playerState) setRecording(rec *playerRecording) {
+	ps.Lock()
+	ps.recording, ps.recordingBusy = rec, false
+	ps.Unlock()
+}
@@ -0,0 +1,347 @@
+package vlc
+
+// #cgo LDFLAGS: -lvlc
+// #include <vlc/vlc.h>
+import "C"
+import (
+	"fmt"
+	"sync"
+)
+
+// RecordingContainer represents the container format of a recording.
+type RecordingContainer string
+
+// Recording containers.
+const (
+	// MPEG transport stream. Recommended for live streams, as the
+	// recorded files remain playable even if they are not finalized.
+	RecordingTS RecordingContainer = "ts"
+
+	// MPEG program stream.
+	RecordingPS RecordingContainer = "ps"
+
+	// MP4 container. The file is only playable after the recording is
+	// stopped.
+	RecordingMP4 RecordingContainer = "mp4"
+
+	// Ogg container.
+	RecordingOGG RecordingContainer = "ogg"
+)
+
+// Validate returns an error if the recording container is not valid.
+func (rc RecordingContainer) Validate() error {
+	switch rc {
+	case RecordingTS, RecordingPS, RecordingMP4, RecordingOGG:
+		return nil
+	}
+
+	return ErrInvalidRecordingContainer
+}
+
+type playerRecording struct {
+	path     string
+	original *Media
+	recorded *Media
+	eventIDs []EventID
+}
+
+// StartRecording starts saving the current media of the player to the
+// file located at the specified path, using the specified container, while
+// it is being played. The player emits a MediaPlayerRecordingStarted event
+// when the recording starts and a MediaPlayerRecordingError event if the
+// recording fails. The payload of the events is a *RecordingPayload.
+// The recording events are emitted after the state of the player is
+// updated, so their callbacks can call the recording methods of the
+// player (e.g. StopRecording).
+//
+// The recording session belongs to the underlying libVLC player, so it is
+// shared by all the Player values which wrap it (e.g. the player returned
+// by the ListPlayer.Player method).
+//
+//	NOTE: libVLC 3 cannot start recording a media which is already being
+//	played. The recording uses a stream output chain which duplicates the
+//	stream to the display and to the output file, and the chain is only
+//	applied when the playback of the media starts. If the player is
+//	playing, its playback is stopped and restarted, which interrupts it
+//	and reconnects to network streams. For seekable media, the playback
+//	time is restored once the playback is restarted, while live streams
+//	resume from their current position. In order to record without
+//	interruption, start recording before starting the playback of the media.
+func (p *Player) StartRecording(path string, container RecordingContainer) error {
+	if err := p.assertInit(); err != nil {
+		return err
+	}
+	if path == "" {
+		return ErrInvalid
+	}
+	if err := container.Validate(); err != nil {
+		return err
+	}
+
+	manager, err := p.EventManager()
+	if err != nil {
+		return err
+	}
+
+	// Reserve the recording session of the player. The state is not locked
+	// while the media is switched, so that the recording methods can be
+	// called from event callbacks.
+	state := p.state()
+	if err := state.reserveRecording(); err != nil {
+		return err
+	}
+
+	rec, err := p.startRecording(manager, path, container)
+	if err != nil {
+		state.setRecording(nil)
+		return err
+	}
+	p.activateRecording(manager, rec)
+	return nil
+}
+
+// startRecording switches the player to a copy of its current media which
+// is saved to the specified path. If the media cannot be switched, the
+// original media is restored and a MediaPlayerRecordingError event is
+// emitted.
+func (p *Player) startRecording(manager *EventManager, path string, container RecordingContainer) (*playerRecording, error) {
+	original, err := p.Media()
+	if err != nil {
+		return nil, err
+	}
+	if original == nil {
+		return nil, ErrMediaNotFound
+	}
+
+	// Create a copy of the media which is played using a stream output
+	// chain that both displays the media and saves it to the output file.
+	recorded, err := original.Duplicate()
+	if err != nil {
+		return nil, err
+	}
+
+	so := NewStreamOutput(&Duplicate{Destinations: []*StreamOutput{
+		NewStreamOutput(&Display{}),
+		NewStreamOutput(&FileOutput{Path: path, Mux: string(container)}),
+	}})
+	if err := recorded.ApplyStreamOutput(so); err != nil {
+		recorded.Release()
+		return nil, err
+	}
+
+	// Keep the original media alive in order to restore it when the
+	// recording is stopped.
+	C.libvlc_media_retain(original.media)
+
+	rec := &playerRecording{
+		path:     path,
+		original: original,
+		recorded: recorded,
+	}
+
+	// Report playback errors which occur while recording. The event is
+	// emitted from a separate goroutine, as player functions cannot be
+	// called from libVLC event callbacks.
+	eventID, err := manager.Attach(MediaPlayerEncounteredError, func(Event, interface{}) {
+		go manager.emit(MediaPlayerRecordingError, &RecordingPayload{
+			Path: path,
+			Err:  fmt.Errorf("%w: playback error", ErrRecordingFailed),
+		})
+	}, nil)
+	if err != nil {
+		p.releaseRecording(rec)
+		return nil, err
+	}
+	rec.eventIDs = append(rec.eventIDs, eventID)
+
+	// Switch to the recorded media. If the player is playing, the playback
+	// is restarted using the recorded media.
+	playing := p.IsPlaying()
+
+	var offset int
+	if playing && p.IsSeekable() {
+		offset, _ = p.MediaTime()
+	}
+
+	if err := p.switchMedia(recorded, playing, offset); err != nil {
+		manager.Detach(rec.eventIDs...)
+		p.switchMedia(original, playing, offset)
+		p.releaseRecording(rec)
+
+		manager.emit(MediaPlayerRecordingError, &RecordingPayload{Path: path, Err: err})
+		return nil, err
+	}
+
+	return rec, nil
+}
+
+// StopRecording stops the active recording session of the player and
+// finalizes the output file. The original media of the player is restored
+// and, if the player is playing when the method is called, its playback is
+// resumed. The player emits a MediaPlayerRecordingStopped event when the
+// recording is stopped.
+//
+//	NOTE: Stopping the recording stops the playback of the player in
+//	order to finalize the output file, so the playback is interrupted.
+func (p *Player) StopRecording() error {
+	if err := p.assertInit(); err != nil {
+		return err
+	}
+
+	manager, err := p.EventManager()
+	if err != nil {
+		return err
+	}
+
+	state := p.state()
+	rec, err := state.takeRecording()
+	if err != nil {
+		return err
+	}
+	manager.Detach(rec.eventIDs...)
+
+	// Stopping the player closes the output file.
+	playing := p.IsPlaying()
+
+	var offset int
+	if playing && p.IsSeekable() {
+		offset, _ = p.MediaTime()
+	}
+
+	stopErr := p.Stop()
+
+	// Restore original media.
+	err = p.switchMedia(rec.original, playing, offset)
+	p.releaseRecording(rec)
+
+	p.deactivateRecording(manager, rec, stopErr)
+	return err
+}
+
+// IsRecording returns true if the player has an active recording session.
+func (p *Player) IsRecording() bool {
+	if err := p.assertInit(); err != nil {
+		return false
+	}
+
+	state := p.state()
+	state.Lock()
+	defer state.Unlock()
+
+	return state.recording != nil
+}
+
+// activateRecording sets the active recording session of the player and
+// emits a MediaPlayerRecordingStarted event. The event is emitted after the
+// state of the player is unlocked.
+func (p *Player) activateRecording(manager *EventManager, rec *playerRecording) {
+	p.state().setRecording(rec)
+	manager.emit(MediaPlayerRecordingStarted, &RecordingPayload{Path: rec.path})
+}
+
+// deactivateRecording releases the recording session of the player, which
+// was stopped, and emits a MediaPlayerRecordingStopped event, preceded by a
+// MediaPlayerRecordingError event if the specified error is not nil. The
+// events are emitted after the state of the player is unlocked.
+func (p *Player) deactivateRecording(manager *EventManager, rec *playerRecording, err error) {
+	p.state().setRecording(nil)
+
+	if err != nil {
+		manager.emit(MediaPlayerRecordingError, &RecordingPayload{Path: rec.path, Err: err})
+	}
+	manager.emit(MediaPlayerRecordingStopped, &RecordingPayload{Path: rec.path})
+}
+
+// switchMedia sets the specified media as the current media of the player.
+// If play is true, the playback of the media is started at the specified
+// offset, in milliseconds.
+func (p *Player) switchMedia(m *Media, play bool, offset int) error {
+	if err := p.Stop(); err != nil {
+		return err
+	}
+	if err := p.SetMedia(m); err != nil {
+		return err
+	}
+	if !play {
+		return nil
+	}
+
+	// libVLC ignores seek requests until the input is started, so the
+	// playback time is set once the playback starts.
+	if offset > 0 {
+		if err := p.seekOnPlaying(offset); err != nil {
+			return err
+		}
+	}
+
+	return p.Play()
+}
+
+// seekOnPlaying sets the playback time of the player, in milliseconds, the
+// next time its playback starts. The request is discarded if the playback
+// stops or fails before it starts.
+func (p *Player) seekOnPlaying(offset int) error {
+	manager, err := p.EventManager()
+	if err != nil {
+		return err
+	}
+
+	var once sync.Once
+	var eventIDs []EventID
+	attached := make(chan struct{})
+
+	player := &Player{player: p.player, inst: p.inst}
+	callback := func(event Event, _ interface{}) {
+		once.Do(func() {
+			// Player functions cannot be called from libVLC event
+			// callbacks, so the playback time is set by a separate
+			// goroutine.
+			go func() {
+				<-attached
+				manager.Detach(eventIDs...)
+
+				if event == MediaPlayerPlaying {
+					player.SetMediaTime(offset)
+				}
+			}()
+		})
+	}
+
+	for _, event := range []Event{MediaPlayerPlaying, MediaPlayerStopped, MediaPlayerEncounteredError} {
+		eventID, err := manager.Attach(event, callback, nil)
+		if err != nil {
+			manager.Detach(eventIDs...)
+			return err
+		}
+		eventIDs = append(eventIDs, eventID)
+	}
+	close(attached)
+
+	return nil
+}
+
+func (p *Player) releaseRecording(rec *playerRecording) {
+	rec.recorded.Release()
+	C.libvlc_media_release(rec.original.media)
+}
+
+// discardRecording releases the active recording session of the player,
+// without restoring the original media.
+func (p *Player) discardRecording() {
+	state := p.state()
+	state.Lock()
+	rec := state.recording
+	state.recording = nil
+	state.Unlock()
+
+	if rec == nil {
+		return
+	}
+
+	if manager, err := p.EventManager(); err == nil {
+		manager.Detach(rec.eventIDs...)
+	}
+
+	p.Stop()
+	p.releaseRecording(rec)
+}
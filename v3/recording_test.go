@@ -0,0 +1,101 @@
+package vlc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecordingEventCallbacks(t *testing.T) {
+	tests := []struct {
+		name  string
+		event Event
+		want  bool
+	}{
+		{name: "started", event: MediaPlayerRecordingStarted, want: true},
+		{name: "error", event: MediaPlayerRecordingError, want: false},
+		{name: "stopped", event: MediaPlayerRecordingStopped, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			player, manager := newTestPlayer(newTestInstance())
+
+			// The callbacks call the recording methods of the player,
+			// which must not block.
+			results := make(chan bool, 1)
+			_, err := manager.Attach(test.event, func(Event, interface{}) {
+				results <- player.IsRecording()
+			}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rec := &playerRecording{path: "out.ts"}
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+
+				if err := player.state().reserveRecording(); err != nil {
+					t.Error(err)
+					return
+				}
+				player.activateRecording(manager, rec)
+
+				if _, err := player.state().takeRecording(); err != nil {
+					t.Error(err)
+					return
+				}
+				player.deactivateRecording(manager, rec, ErrRecordingFailed)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("recording event callback deadlocked")
+			}
+
+			select {
+			case got := <-results:
+				if got != test.want {
+					t.Errorf("IsRecording: got %t, want %t", got, test.want)
+				}
+			default:
+				t.Fatal("recording event not emitted")
+			}
+		})
+	}
+}
+
+func TestRecordingState(t *testing.T) {
+	state := &playerState{}
+
+	if _, err := state.takeRecording(); err != ErrRecordingNotActive {
+		t.Fatalf("take without recording: got %v, want %v", err, ErrRecordingNotActive)
+	}
+	if err := state.reserveRecording(); err != nil {
+		t.Fatal(err)
+	}
+	if err := state.reserveRecording(); err != ErrRecordingActive {
+		t.Fatalf("reserve while starting: got %v, want %v", err, ErrRecordingActive)
+	}
+	if _, err := state.takeRecording(); err != ErrRecordingNotActive {
+		t.Fatalf("take while starting: got %v, want %v", err, ErrRecordingNotActive)
+	}
+
+	rec := &playerRecording{}
+	state.setRecording(rec)
+	if err := state.reserveRecording(); err != ErrRecordingActive {
+		t.Fatalf("reserve while recording: got %v, want %v", err, ErrRecordingActive)
+	}
+	if got, err := state.takeRecording(); err != nil || got != rec {
+		t.Fatalf("take while recording: got %v, %v", got, err)
+	}
+	if err := state.reserveRecording(); err != ErrRecordingActive {
+		t.Fatalf("reserve while stopping: got %v, want %v", err, ErrRecordingActive)
+	}
+
+	state.setRecording(nil)
+	if err := state.reserveRecording(); err != nil {
+		t.Fatalf("reserve after stop: %v", err)
+	}
+}
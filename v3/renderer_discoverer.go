@@ -27,14 +27,20 @@ type RendererDiscovererDescriptor struct {
 // ListRendererDiscoverers returns a list of descriptors identifying the
 // available renderer discovery services.
 func ListRendererDiscoverers() ([]*RendererDiscovererDescriptor, error) {
-	if err := inst.assertInit(); err != nil {
+	return inst.ListRendererDiscoverers()
+}
+
+// ListRendererDiscoverers returns a list of descriptors identifying the
+// renderer discovery services available to the instance.
+func (i *Instance) ListRendererDiscoverers() ([]*RendererDiscovererDescriptor, error) {
+	if err := i.assertInit(); err != nil {
 		return nil, err
 	}
 
 	// Get renderer discoverer descriptors.
 	var cDescriptors **C.libvlc_rd_description_t
 
-	count := int(C.libvlc_renderer_discoverer_list_get(inst.handle, &cDescriptors))
+	count := int(C.libvlc_renderer_discoverer_list_get(i.handle, &cDescriptors))
 	if count <= 0 || cDescriptors == nil {
 		return nil, nil
 	}
@@ -72,6 +78,7 @@ type RendererDiscoverer struct {
 	discoverer *C.libvlc_renderer_discoverer_t
 	renderers  map[*C.libvlc_renderer_item_t]*Renderer
 	stopFunc   func()
+	inst       *Instance
 }
 
 // NewRendererDiscoverer instantiates the renderer discovery service
@@ -81,14 +88,20 @@ type RendererDiscoverer struct {
 //	NOTE: Call the Release method on the discovery service instance in
 //	order to free the allocated resources.
 func NewRendererDiscoverer(name string) (*RendererDiscoverer, error) {
-	if err := inst.assertInit(); err != nil {
+	return inst.NewRendererDiscoverer(name)
+}
+
+// NewRendererDiscoverer instantiates the renderer discovery service
+// identified by the specified name, using the instance.
+func (i *Instance) NewRendererDiscoverer(name string) (*RendererDiscoverer, error) {
+	if err := i.assertInit(); err != nil {
 		return nil, err
 	}
 
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 
-	discoverer := C.libvlc_renderer_discoverer_new(inst.handle, cName)
+	discoverer := C.libvlc_renderer_discoverer_new(i.handle, cName)
 	if discoverer == nil {
 		return nil, errOrDefault(getError(), ErrRendererDiscovererCreate)
 	}
@@ -96,11 +109,13 @@ func NewRendererDiscoverer(name string) (*RendererDiscoverer, error) {
 	return &RendererDiscoverer{
 		discoverer: discoverer,
 		renderers:  map[*C.libvlc_renderer_item_t]*Renderer{},
+		inst:       i,
 	}, nil
 }
 
 // Release stops and destroys the renderer discovery service along
-// with all the renderers found by the instance.
+// with all the renderers found by the instance. The events registered
+// through the event manager of the discovery service are detached.
 func (rd *RendererDiscoverer) Release() error {
 	if err := rd.assertInit(); err != nil {
 		return nil
@@ -108,6 +123,7 @@ func (rd *RendererDiscoverer) Release() error {
 
 	// Stop discovery service.
 	rd.stop()
+	rd.inst.detachEvents(C.libvlc_renderer_discoverer_event_manager(rd.discoverer))
 
 	// Release renderers.
 	for _, renderer := range rd.renderers {
@@ -239,7 +255,7 @@ func (rd *RendererDiscoverer) eventManager() (*EventManager, error) {
 		return nil, ErrMissingEventManager
 	}
 
-	return newEventManager(manager), nil
+	return newEventManager(rd.inst, manager), nil
 }
 
 func (rd *RendererDiscoverer) assertInit() error {
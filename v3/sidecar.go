@@ -0,0 +1,354 @@
+package vlc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultSidecarExtensions contains the file extensions of the subtitle
+// formats detected by default by the sidecar subtitle discovery.
+var DefaultSidecarExtensions = []string{"srt", "ass", "ssa", "vtt", "sub", "smi", "txt"}
+
+// DefaultSidecarDirectories contains the names of the subdirectories of the
+// media directory which are scanned by default by the sidecar subtitle
+// discovery. The names are matched case-insensitively.
+var DefaultSidecarDirectories = []string{"subs", "subtitles"}
+
+// SidecarRules contains the rules used to discover the subtitle files
+// located next to a local media file.
+type SidecarRules struct {
+	// Extensions of the subtitle files, without the leading dot. The
+	// extensions are matched case-insensitively. If empty, the
+	// DefaultSidecarExtensions are used.
+	Extensions []string
+
+	// Names of the subdirectories of the media directory which are also
+	// scanned. Subdirectories of them which are named after the media
+	// (e.g. Subs/movie/) are scanned as well. If empty, the
+	// DefaultSidecarDirectories are used.
+	Directories []string
+
+	// Preferred subtitle languages (e.g. en, fre, spanish), in order of
+	// preference. The candidates are ranked based on their language.
+	Languages []string
+
+	// Accept subtitle files whose names do not start with the name of the
+	// media. By default, the names of the subtitle files located in the
+	// media directory and in the subtitle subdirectories must start with
+	// the name of the media, followed by a separator (e.g. movie.en.srt,
+	// movie_en.srt, but not movie2.en.srt). The files located in the
+	// subdirectories named after the media (e.g. Subs/movie/) are always
+	// accepted.
+	MatchAnyName bool
+}
+
+// SidecarSubtitle represents a subtitle file discovered next to a local
+// media file.
+type SidecarSubtitle struct {
+	// Path of the subtitle file.
+	Path string
+
+	// Detected language of the subtitles, as an ISO 639-1 code when it is
+	// known (e.g. en, fr). Empty if no language is detected.
+	Language string
+
+	// Format of the subtitles, based on the file extension (e.g. srt, ass).
+	Format string
+
+	// Forced subtitles, which only cover foreign dialogue or on-screen text.
+	Forced bool
+
+	// Subtitles for the deaf and hard of hearing, which also describe
+	// non-dialogue audio (e.g. movie.en.sdh.srt, movie.hi.srt).
+	HearingImpaired bool
+}
+
+// SetSidecarDiscovery enables the automatic discovery of the subtitle files
+// located next to the local media created using the NewMediaFromPath
+// function or the Player.LoadMediaFromPath method. The subtitle files are
+// discovered using the provided rules and are attached to the media, as
+// described by the Media.AttachSidecarSubtitles method. The discovery is
+// best-effort: if the subtitle files cannot be discovered or attached, the
+// media is created without them. Pass in nil in order to disable the
+// discovery. By default, the discovery is disabled.
+func SetSidecarDiscovery(rules *SidecarRules) error {
+	return inst.SetSidecarDiscovery(rules)
+}
+
+// SetSidecarDiscovery enables the automatic discovery of the subtitle files
+// located next to the local media created using the instance. Pass in nil
+// in order to disable the discovery. See the SetSidecarDiscovery function.
+func (i *Instance) SetSidecarDiscovery(rules *SidecarRules) error {
+	if err := i.assertInit(); err != nil {
+		return err
+	}
+
+	var discovery *SidecarRules
+	if rules != nil {
+		discovery = &SidecarRules{
+			Extensions:   append([]string(nil), rules.Extensions...),
+			Directories:  append([]string(nil), rules.Directories...),
+			Languages:    append([]string(nil), rules.Languages...),
+			MatchAnyName: rules.MatchAnyName,
+		}
+	}
+
+	i.sidecarMu.Lock()
+	i.sidecarRules = discovery
+	i.sidecarMu.Unlock()
+
+	return nil
+}
+
+// FindSidecarSubtitles returns the subtitle files located next to the
+// media file at the specified path, discovered using the provided rules.
+// If no rules are provided, the default rules are used. The candidates are
+// ranked by their language, based on the preferred languages of the rules.
+// Candidates located in the media directory precede the ones located in
+// the subtitle subdirectories, and forced subtitles are ranked last.
+func FindSidecarSubtitles(path string, rules *SidecarRules) ([]*SidecarSubtitle, error) {
+	if rules == nil {
+		rules = &SidecarRules{}
+	}
+
+	extensions := rules.Extensions
+	if len(extensions) == 0 {
+		extensions = DefaultSidecarExtensions
+	}
+	directories := rules.Directories
+	if len(directories) == 0 {
+		directories = DefaultSidecarDirectories
+	}
+
+	dir := filepath.Dir(path)
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []*sidecarCandidate
+	for _, file := range files {
+		name := file.Name()
+
+		// Scan subtitle subdirectories.
+		if file.IsDir() {
+			if !containsFold(directories, name) {
+				continue
+			}
+
+			subdir := filepath.Join(dir, name)
+			candidates = append(candidates, scanSidecarDirectory(subdir, base, extensions, 1, !rules.MatchAnyName)...)
+
+			// Scan subdirectory named after the media (e.g. Subs/movie/).
+			if info, err := os.Stat(filepath.Join(subdir, base)); err == nil && info.IsDir() {
+				candidates = append(candidates, scanSidecarDirectory(filepath.Join(subdir, base), base, extensions, 2, false)...)
+			}
+			continue
+		}
+
+		if !rules.MatchAnyName && !hasMediaName(name, base) {
+			continue
+		}
+		if candidate := newSidecarCandidate(filepath.Join(dir, name), base, extensions, 0); candidate != nil {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	// Rank candidates.
+	for _, candidate := range candidates {
+		candidate.rank = languageRank(candidate.Language, rules.Languages)
+		if candidate.rank < 0 {
+			candidate.rank = len(rules.Languages)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		ci, cj := candidates[i], candidates[j]
+		switch {
+		case ci.Forced != cj.Forced:
+			return !ci.Forced
+		case ci.rank != cj.rank:
+			return ci.rank < cj.rank
+		case ci.depth != cj.depth:
+			return ci.depth < cj.depth
+		}
+
+		return ci.Path < cj.Path
+	})
+
+	subtitles := make([]*SidecarSubtitle, 0, len(candidates))
+	for _, candidate := range candidates {
+		subtitles = append(subtitles, &candidate.SidecarSubtitle)
+	}
+
+	return subtitles, nil
+}
+
+// AttachSidecarSubtitles discovers the subtitle files located next to the
+// media, using the FindSidecarSubtitles function, and adds them to the
+// media as slaves. The first ranked subtitle file is added with the highest
+// priority, so it is selected by default. The method returns the attached
+// subtitle files. The media must be a local file (e.g. created using the
+// NewMediaFromPath function or the Player.LoadMediaFromPath method).
+//
+//	NOTE: The subtitle files must be attached before the media is played.
+func (m *Media) AttachSidecarSubtitles(rules *SidecarRules) ([]*SidecarSubtitle, error) {
+	if err := m.assertInit(); err != nil {
+		return nil, err
+	}
+
+	path, err := m.Location()
+	if err != nil {
+		return nil, err
+	}
+	if !filepath.IsAbs(path) {
+		return nil, ErrMediaNotLocal
+	}
+
+	subtitles, err := FindSidecarSubtitles(path, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, subtitle := range subtitles {
+		uri, err := pathToURL(subtitle.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		priority := uint(MaxMediaSlavePriority)
+		if i > 0 {
+			priority--
+		}
+		if err := m.AddSlave(MediaSlaveSubtitle, uri, priority); err != nil {
+			return nil, err
+		}
+	}
+
+	return subtitles, nil
+}
+
+type sidecarCandidate struct {
+	SidecarSubtitle
+	depth int
+	rank  int
+}
+
+func scanSidecarDirectory(dir, base string, extensions []string, depth int, matchName bool) []*sidecarCandidate {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var candidates []*sidecarCandidate
+	for _, file := range files {
+		if file.IsDir() || (matchName && !hasMediaName(file.Name(), base)) {
+			continue
+		}
+
+		if candidate := newSidecarCandidate(filepath.Join(dir, file.Name()), base, extensions, depth); candidate != nil {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	return candidates
+}
+
+func newSidecarCandidate(path, base string, extensions []string, depth int) *sidecarCandidate {
+	name := filepath.Base(path)
+
+	ext := strings.TrimPrefix(filepath.Ext(name), ".")
+	if !containsFold(extensions, ext) {
+		return nil
+	}
+	format := strings.ToLower(ext)
+
+	// Parse the tokens of the name which follow the media name
+	// (e.g. movie.en.forced.srt, 2_English.srt).
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	if hasMediaName(name, base) {
+		name = name[len(base):]
+	}
+
+	candidate := &sidecarCandidate{
+		SidecarSubtitle: SidecarSubtitle{Path: path, Format: format},
+		depth:           depth,
+	}
+
+	tokens := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '.' || r == '_' || r == '-' || r == ' ' || r == '[' || r == ']' || r == '(' || r == ')'
+	})
+	for _, token := range tokens {
+		switch {
+		case strings.EqualFold(token, "forced"):
+			candidate.Forced = true
+		case isHearingImpairedTag(token):
+			// Checked before the languages, as `hi` is also the
+			// ISO 639-1 code of Hindi.
+			candidate.HearingImpaired = true
+		case candidate.Language == "" && isLanguage(token):
+			candidate.Language = normalizeLanguage(token)
+		}
+	}
+
+	return candidate
+}
+
+// attachSidecarSubtitles attaches the subtitle files located next to the
+// specified local media, if the automatic sidecar discovery is enabled.
+// Discovery errors are ignored.
+func (i *Instance) attachSidecarSubtitles(m *Media) {
+	i.sidecarMu.Lock()
+	rules := i.sidecarRules
+	i.sidecarMu.Unlock()
+
+	if rules != nil {
+		m.AttachSidecarSubtitles(rules)
+	}
+}
+
+func isHearingImpairedTag(token string) bool {
+	return strings.EqualFold(token, "hi") ||
+		strings.EqualFold(token, "sdh") ||
+		strings.EqualFold(token, "cc")
+}
+
+// hasMediaName returns true if the specified file name starts with the
+// provided media name, ignoring case, followed by a separator (e.g.
+// `movie.en.srt` or `movie (en).srt` for `movie`, but not `movie2.en.srt`).
+func hasMediaName(name, base string) bool {
+	if !hasPrefixFold(name, base) {
+		return false
+	}
+	if len(name) == len(base) {
+		return true
+	}
+
+	switch name[len(base)] {
+	case '.', '_', '-', ' ', '[', '(':
+		return true
+	}
+
+	return false
+}
+
+// hasPrefixFold returns true if the specified string starts with the
+// provided prefix, ignoring case. Unlike comparing the lowercase forms of
+// the strings, the length of the matched prefix is always len(prefix).
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
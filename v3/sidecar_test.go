@@ -0,0 +1,214 @@
+package vlc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewSidecarCandidate(t *testing.T) {
+	tests := []struct {
+		name            string
+		file            string
+		base            string
+		ok              bool
+		language        string
+		format          string
+		forced          bool
+		hearingImpaired bool
+	}{
+		{name: "plain", file: "movie.srt", base: "movie", ok: true, format: "srt"},
+		{name: "language code", file: "movie.en.srt", base: "movie", ok: true, language: "en", format: "srt"},
+		{name: "language name", file: "2_English.srt", base: "movie", ok: true, language: "en", format: "srt"},
+		{name: "ISO 639-2 code", file: "movie.fre.ass", base: "movie", ok: true, language: "fr", format: "ass"},
+		{name: "uppercase extension", file: "movie.de.SRT", base: "movie", ok: true, language: "de", format: "srt"},
+		{name: "forced", file: "movie.en.forced.srt", base: "movie", ok: true, language: "en", format: "srt", forced: true},
+		{name: "hearing impaired", file: "movie.hi.srt", base: "movie", ok: true, format: "srt", hearingImpaired: true},
+		{name: "SDH", file: "movie.en.sdh.srt", base: "movie", ok: true, language: "en", format: "srt", hearingImpaired: true},
+		{name: "closed captions", file: "movie.es.CC.vtt", base: "movie", ok: true, language: "es", format: "vtt", hearingImpaired: true},
+		{name: "hindi name", file: "movie.hindi.srt", base: "movie", ok: true, language: "hi", format: "srt"},
+		{name: "case-insensitive media name", file: "MOVIE.it.srt", base: "movie", ok: true, language: "it", format: "srt"},
+		{name: "media name is a language", file: "english.srt", base: "english", ok: true, format: "srt"},
+		{name: "Kelvin sign", file: "\u212Aelvin.fr.srt", base: "Kelvin", ok: true, language: "fr", format: "srt"},
+		{name: "Kelvin sign base", file: "kelvin.fr.srt", base: "\u212Aelvin", ok: true, language: "fr", format: "srt"},
+		{name: "dotted capital I", file: "İstanbul.tr.srt", base: "İstanbul", ok: true, language: "tr", format: "srt"},
+		{name: "dotted capital I lowercase", file: "istanbul.ru.srt", base: "İstanbul", ok: true, language: "ru", format: "srt"},
+		{name: "short name", file: "a.srt", base: "İstanbul", ok: true, format: "srt"},
+		{name: "unknown extension", file: "movie.en.mkv", base: "movie"},
+		{name: "no extension", file: "movie", base: "movie"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join("dir", test.file)
+			candidate := newSidecarCandidate(path, test.base, DefaultSidecarExtensions, 0)
+			if !test.ok {
+				if candidate != nil {
+					t.Fatalf("expected no candidate, got %+v", candidate.SidecarSubtitle)
+				}
+				return
+			}
+			if candidate == nil {
+				t.Fatal("expected candidate, got nil")
+			}
+
+			if candidate.Path != path {
+				t.Errorf("path: got %q, want %q", candidate.Path, path)
+			}
+			if candidate.Language != test.language {
+				t.Errorf("language: got %q, want %q", candidate.Language, test.language)
+			}
+			if candidate.Format != test.format {
+				t.Errorf("format: got %q, want %q", candidate.Format, test.format)
+			}
+			if candidate.Forced != test.forced {
+				t.Errorf("forced: got %t, want %t", candidate.Forced, test.forced)
+			}
+			if candidate.HearingImpaired != test.hearingImpaired {
+				t.Errorf("hearing impaired: got %t, want %t", candidate.HearingImpaired, test.hearingImpaired)
+			}
+		})
+	}
+}
+
+func TestFindSidecarSubtitles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "libvlc-go-sidecar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := []string{
+		"Episode 1.mkv",
+		"Episode 10.mkv",
+		"Episode 1.en.srt",
+		"episode 1.fr.forced.srt",
+		"Episode 1 (de).srt",
+		"Episode 1_es.srt",
+		"Episode 1.notes.txt.bak",
+		"Episode 10.en.srt",
+		"Episode 11.fr.srt",
+		"Episode 1x.it.srt",
+		"Other.en.srt",
+		"\u212Aelvin.en.srt",
+		"Subs/Episode 1.it.srt",
+		"Subs/Episode 10.it.srt",
+		"Subs/English.srt",
+		"Subs/Episode 1/2_Portuguese.srt",
+		"Subs/Episode 10/2_Portuguese.srt",
+	}
+	for _, file := range files {
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		rules *SidecarRules
+		want  []string
+	}{
+		{
+			name: "default rules",
+			want: []string{
+				"Episode 1 (de).srt", "Episode 1.en.srt", "Episode 1_es.srt",
+				"Subs/Episode 1.it.srt", "Subs/Episode 1/2_Portuguese.srt", "episode 1.fr.forced.srt",
+			},
+		},
+		{
+			name:  "preferred languages",
+			rules: &SidecarRules{Languages: []string{"portuguese", "eng"}},
+			want: []string{
+				"Subs/Episode 1/2_Portuguese.srt", "Episode 1.en.srt", "Episode 1 (de).srt",
+				"Episode 1_es.srt", "Subs/Episode 1.it.srt", "episode 1.fr.forced.srt",
+			},
+		},
+		{
+			name:  "custom directories",
+			rules: &SidecarRules{Directories: []string{"none"}, Languages: []string{"en"}},
+			want:  []string{"Episode 1.en.srt", "Episode 1 (de).srt", "Episode 1_es.srt", "episode 1.fr.forced.srt"},
+		},
+		{
+			name:  "any name",
+			rules: &SidecarRules{Directories: []string{"none"}, Languages: []string{"en"}, MatchAnyName: true},
+			want: []string{
+				"Episode 1.en.srt", "Episode 10.en.srt", "Other.en.srt", "\u212Aelvin.en.srt",
+				"Episode 1 (de).srt", "Episode 11.fr.srt", "Episode 1_es.srt", "Episode 1x.it.srt",
+				"episode 1.fr.forced.srt",
+			},
+		},
+		{
+			name:  "any name in subdirectories",
+			rules: &SidecarRules{Languages: []string{"it", "en"}, MatchAnyName: true, Extensions: []string{"srt"}},
+			want: []string{
+				"Episode 1x.it.srt", "Subs/Episode 1.it.srt", "Subs/Episode 10.it.srt",
+				"Episode 1.en.srt", "Episode 10.en.srt", "Other.en.srt", "\u212Aelvin.en.srt", "Subs/English.srt",
+				"Episode 1 (de).srt", "Episode 11.fr.srt", "Episode 1_es.srt", "Subs/Episode 1/2_Portuguese.srt",
+				"episode 1.fr.forced.srt",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			subtitles, err := FindSidecarSubtitles(filepath.Join(dir, "Episode 1.mkv"), test.rules)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := make([]string, 0, len(subtitles))
+			for _, subtitle := range subtitles {
+				rel, err := filepath.Rel(dir, subtitle.Path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, filepath.ToSlash(rel))
+			}
+
+			if len(got) != len(test.want) {
+				t.Fatalf("got %q, want %q", got, test.want)
+			}
+			for i := range got {
+				if got[i] != test.want[i] {
+					t.Fatalf("got %q, want %q", got, test.want)
+				}
+			}
+		})
+	}
+}
+
+func TestHasMediaName(t *testing.T) {
+	tests := []struct {
+		name string
+		base string
+		want bool
+	}{
+		{name: "Episode 1", base: "Episode 1", want: true},
+		{name: "Episode 1.en.srt", base: "Episode 1", want: true},
+		{name: "episode 1_en.srt", base: "Episode 1", want: true},
+		{name: "Episode 1-en.srt", base: "Episode 1", want: true},
+		{name: "Episode 1 en.srt", base: "Episode 1", want: true},
+		{name: "Episode 1[en].srt", base: "Episode 1", want: true},
+		{name: "Episode 1(en).srt", base: "Episode 1", want: true},
+		{name: "Episode 10.en.srt", base: "Episode 1"},
+		{name: "Episode 11.fr.srt", base: "Episode 1"},
+		{name: "Episode 1x.srt", base: "Episode 1"},
+		{name: "Episode", base: "Episode 1"},
+		{name: "\u212Aelvin.en.srt", base: "Kelvin"},
+		{name: "İstanbul.tr.srt", base: "İstanbul", want: true},
+		{name: "İstanbul2.tr.srt", base: "İstanbul"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := hasMediaName(test.name, test.base); got != test.want {
+				t.Errorf("hasMediaName(%q, %q): got %t, want %t", test.name, test.base, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package vlc
+
+import (
+	"context"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultSnapshotTimeout is the amount of time the TakeSnapshotWithFormat
+// method waits for a snapshot to be taken.
+const DefaultSnapshotTimeout = 10 * time.Second
+
+// SnapshotFormat represents the image format of a video snapshot.
+type SnapshotFormat uint
+
+// Snapshot formats.
+const (
+	SnapshotPNG SnapshotFormat = iota
+	SnapshotJPEG
+)
+
+// Validate returns an error if the snapshot format is not valid.
+func (sf SnapshotFormat) Validate() error {
+	if sf > SnapshotJPEG {
+		return ErrInvalidSnapshotFormat
+	}
+
+	return nil
+}
+
+// Snapshot takes a snapshot of the current video and returns it as an image.
+// The width and height values are interpreted in the same way as in the case
+// of the TakeSnapshot method. If the player uses a video sink, the snapshot
+// is a copy of the next frame delivered to the sink. Otherwise, the snapshot
+// is saved to a temporary file, which is decoded and removed after the
+// MediaPlayerSnapshotTaken event is received. The method returns when the
+// snapshot is available or when the provided context is done.
+func (p *Player) Snapshot(ctx context.Context, width, height uint) (image.Image, error) {
+	if err := p.assertInit(); err != nil {
+		return nil, err
+	}
+
+	// Use the video sink of the player, if set.
+	if obj, ok := p.inst.objects.get(p.videoSinkID); ok {
+		if sinkCtx, ok := obj.(*videoSinkContext); ok {
+			return snapshotVideoSink(ctx, sinkCtx, width, height)
+		}
+	}
+
+	// Create temporary snapshot file.
+	f, err := ioutil.TempFile("", "vlc-snapshot-*.png")
+	if err != nil {
+		return nil, err
+	}
+	path := f.Name()
+	f.Close()
+	defer os.Remove(path)
+
+	// Take snapshot and wait for it to be saved.
+	if err := p.takeSnapshot(ctx, path, width, height); err != nil {
+		return nil, err
+	}
+
+	if f, err = os.Open(path); err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return png.Decode(f)
+}
+
+// TakeSnapshotWithFormat takes a snapshot of the current video and saves it
+// at the specified output path, using the specified image format. The width
+// and height values are interpreted in the same way as in the case of the
+// TakeSnapshot method. Unlike TakeSnapshot, the method waits for the snapshot
+// to be saved, for up to DefaultSnapshotTimeout.
+func (p *Player) TakeSnapshotWithFormat(outputPath string, width, height uint, format SnapshotFormat) error {
+	if err := p.assertInit(); err != nil {
+		return err
+	}
+	if err := format.Validate(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultSnapshotTimeout)
+	defer cancel()
+
+	if format == SnapshotPNG {
+		return p.takeSnapshot(ctx, outputPath, width, height)
+	}
+
+	// libVLC only produces PNG snapshots. Re-encode the snapshot.
+	img, err := p.Snapshot(ctx, width, height)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(outputPath)
+	if err != nil {
+		return err
+	}
+	if err := jpeg.Encode(f, img, nil); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+func (p *Player) takeSnapshot(ctx context.Context, outputPath string, width, height uint) error {
+	manager, err := p.EventManager()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events, err := manager.Subscribe(ctx, MediaPlayerSnapshotTaken)
+	if err != nil {
+		return err
+	}
+	if err := p.TakeSnapshot(outputPath, width, height); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case info, ok := <-events:
+			if !ok {
+				return ctx.Err()
+			}
+
+			payload, ok := info.Payload.(*SnapshotPayload)
+			if ok && filepath.Clean(payload.Path) == filepath.Clean(outputPath) {
+				return nil
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func snapshotVideoSink(ctx context.Context, sinkCtx *videoSinkContext, width, height uint) (image.Image, error) {
+	snapshot := sinkCtx.snapshot()
+
+	select {
+	case img := <-snapshot:
+		if img == nil {
+			return nil, ErrVideoSnapshot
+		}
+
+		return scaleImage(img, width, height), nil
+	case <-ctx.Done():
+		sinkCtx.cancelSnapshot(snapshot)
+		return nil, ctx.Err()
+	}
+}
+
+// scaleImage resizes the specified image using nearest-neighbor sampling.
+// If one of the dimensions is 0, it is calculated based on the other one,
+// in order to preserve the aspect ratio of the image. If both dimensions
+// are 0, the image is returned unchanged.
+func scaleImage(src *image.RGBA, width, height uint) *image.RGBA {
+	bounds := src.Bounds()
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+	if srcWidth == 0 || srcHeight == 0 {
+		return src
+	}
+
+	dstWidth, dstHeight := int(width), int(height)
+	switch {
+	case dstWidth == 0 && dstHeight == 0:
+		return src
+	case dstWidth == 0:
+		dstWidth = srcWidth * dstHeight / srcHeight
+	case dstHeight == 0:
+		dstHeight = srcHeight * dstWidth / srcWidth
+	}
+	if dstWidth == srcWidth && dstHeight == srcHeight {
+		return src
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		srcRow := src.Pix[(y*srcHeight/dstHeight)*src.Stride:]
+		dstRow := dst.Pix[y*dst.Stride:]
+		for x := 0; x < dstWidth; x++ {
+			i := (x * srcWidth / dstWidth) * 4
+			copy(dstRow[x*4:x*4+4], srcRow[i:i+4])
+		}
+	}
+
+	return dst
+}
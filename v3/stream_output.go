@@ -0,0 +1,445 @@
+package vlc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// StreamOutputModule represents a module of a stream output chain
+// (e.g. transcode, duplicate, display, std, rtp).
+type StreamOutputModule interface {
+	// Validate returns an error if the configuration of the module
+	// is not valid.
+	Validate() error
+
+	render() string
+}
+
+// StreamOutput represents a chain of stream output modules, which
+// determines how a media is transcoded, displayed and streamed. The
+// modules are applied in the order in which they are added to the chain.
+// Use the Media.ApplyStreamOutput method in order to use the stream output
+// when playing a media.
+//
+// For example, the following chain transcodes the media to H.264/AAC and
+// saves it to a file, while also displaying it:
+//
+//	vlc.NewStreamOutput(
+//		&vlc.Transcode{VideoCodec: "h264", AudioCodec: "mp4a", AudioBitrate: 128},
+//		&vlc.Duplicate{Destinations: []*vlc.StreamOutput{
+//			vlc.NewStreamOutput(&vlc.Display{}),
+//			vlc.NewStreamOutput(&vlc.FileOutput{Path: "out.mp4", Mux: "mp4"}),
+//		}},
+//	)
+type StreamOutput struct {
+	modules []StreamOutputModule
+}
+
+// NewStreamOutput returns a new stream output chain containing the
+// specified modules.
+func NewStreamOutput(modules ...StreamOutputModule) *StreamOutput {
+	return &StreamOutput{modules: modules}
+}
+
+// Add appends the specified module to the chain. The method returns the
+// stream output in order to allow chaining calls.
+func (so *StreamOutput) Add(module StreamOutputModule) *StreamOutput {
+	so.modules = append(so.modules, module)
+	return so
+}
+
+// Modules returns the modules of the chain.
+func (so *StreamOutput) Modules() []StreamOutputModule {
+	return so.modules
+}
+
+// Validate returns an error if the chain is empty or if any of its
+// modules is not valid.
+func (so *StreamOutput) Validate() error {
+	if so == nil || len(so.modules) == 0 {
+		return fmt.Errorf("%w: empty chain", ErrInvalidStreamOutput)
+	}
+
+	for _, module := range so.modules {
+		if module == nil {
+			return fmt.Errorf("%w: nil module", ErrInvalidStreamOutput)
+		}
+		if err := module.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Render validates the chain and returns its textual representation
+// (e.g. `#transcode{vcodec=h264}:std{access=file,mux=mp4,dst=out.mp4}`).
+func (so *StreamOutput) Render() (string, error) {
+	if err := so.Validate(); err != nil {
+		return "", err
+	}
+
+	return "#" + so.chain(), nil
+}
+
+// String returns the textual representation of the chain, without
+// validating it.
+func (so *StreamOutput) String() string {
+	if so == nil {
+		return ""
+	}
+
+	return "#" + so.chain()
+}
+
+func (so *StreamOutput) chain() string {
+	modules := make([]string, 0, len(so.modules))
+	for _, module := range so.modules {
+		if module != nil {
+			modules = append(modules, module.render())
+		}
+	}
+
+	return strings.Join(modules, ":")
+}
+
+// ApplyStreamOutput validates the specified stream output chain and sets
+// it as the stream output of the media.
+//
+//	NOTE: The stream output is used the next time the media is played.
+//	Media options cannot be removed once added.
+func (m *Media) ApplyStreamOutput(so *StreamOutput) error {
+	if err := m.assertInit(); err != nil {
+		return err
+	}
+
+	chain, err := so.Render()
+	if err != nil {
+		return err
+	}
+
+	return m.AddOptions(":sout=" + chain)
+}
+
+// Transcode represents the transcode stream output module, which converts
+// the video and audio streams to different codecs. The zero values of the
+// fields are not included in the chain, so the defaults of libVLC are used.
+// Video and audio codecs are specified using their libVLC names
+// (e.g. h264, VP80, mp4v, mp4a, vorb, opus, mpga).
+type Transcode struct {
+	VideoCodec   string  // Video codec (vcodec).
+	VideoBitrate uint    // Video bitrate, in kb/s (vb).
+	Scale        float64 // Video scaling factor (scale).
+	Width        uint    // Video width, in pixels (width).
+	Height       uint    // Video height, in pixels (height).
+	FPS          float64 // Video frame rate (fps).
+
+	AudioCodec    string // Audio codec (acodec).
+	AudioBitrate  uint   // Audio bitrate, in kb/s (ab).
+	AudioChannels uint   // Number of audio channels (channels).
+	SampleRate    uint   // Audio sample rate, in Hz (samplerate).
+
+	SubtitleCodec   string // Subtitle codec (scodec).
+	SubtitleOverlay bool   // Render subtitles over the video (soverlay).
+}
+
+// Validate returns an error if the configuration of the module is not valid.
+func (t *Transcode) Validate() error {
+	if t.VideoCodec == "" && t.AudioCodec == "" && t.SubtitleCodec == "" && !t.SubtitleOverlay {
+		return fmt.Errorf("%w: transcode: no codec specified", ErrInvalidStreamOutput)
+	}
+	if err := validateCodec("vcodec", t.VideoCodec); err != nil {
+		return err
+	}
+	if err := validateCodec("acodec", t.AudioCodec); err != nil {
+		return err
+	}
+	if err := validateCodec("scodec", t.SubtitleCodec); err != nil {
+		return err
+	}
+	if t.Scale < 0 {
+		return fmt.Errorf("%w: transcode: invalid scale %g", ErrInvalidStreamOutput, t.Scale)
+	}
+	if t.FPS < 0 {
+		return fmt.Errorf("%w: transcode: invalid frame rate %g", ErrInvalidStreamOutput, t.FPS)
+	}
+
+	return nil
+}
+
+func (t *Transcode) render() string {
+	opts := &soutOptions{}
+	opts.add("vcodec", t.VideoCodec)
+	opts.addUint("vb", t.VideoBitrate)
+	opts.addFloat("scale", t.Scale)
+	opts.addUint("width", t.Width)
+	opts.addUint("height", t.Height)
+	opts.addFloat("fps", t.FPS)
+	opts.add("acodec", t.AudioCodec)
+	opts.addUint("ab", t.AudioBitrate)
+	opts.addUint("channels", t.AudioChannels)
+	opts.addUint("samplerate", t.SampleRate)
+	opts.add("scodec", t.SubtitleCodec)
+	opts.addFlag("soverlay", t.SubtitleOverlay)
+
+	return opts.render("transcode")
+}
+
+// Duplicate represents the duplicate stream output module, which sends
+// the stream to multiple destination chains.
+type Duplicate struct {
+	Destinations []*StreamOutput
+}
+
+// Validate returns an error if the configuration of the module is not valid.
+func (d *Duplicate) Validate() error {
+	if len(d.Destinations) == 0 {
+		return fmt.Errorf("%w: duplicate: no destinations specified", ErrInvalidStreamOutput)
+	}
+
+	for _, dst := range d.Destinations {
+		if err := dst.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *Duplicate) render() string {
+	dsts := make([]string, 0, len(d.Destinations))
+	for _, dst := range d.Destinations {
+		dsts = append(dsts, "dst="+dst.chain())
+	}
+
+	return "duplicate{" + strings.Join(dsts, ",") + "}"
+}
+
+// Display represents the display stream output module, which plays
+// the stream locally.
+type Display struct {
+	NoAudio bool // Do not play the audio stream.
+	NoVideo bool // Do not play the video stream.
+}
+
+// Validate returns an error if the configuration of the module is not valid.
+func (d *Display) Validate() error {
+	if d.NoAudio && d.NoVideo {
+		return fmt.Errorf("%w: display: both audio and video are disabled", ErrInvalidStreamOutput)
+	}
+
+	return nil
+}
+
+func (d *Display) render() string {
+	opts := &soutOptions{}
+	opts.addFlag("noaudio", d.NoAudio)
+	opts.addFlag("novideo", d.NoVideo)
+
+	return opts.render("display")
+}
+
+// StandardOutput represents the std stream output module, which sends the
+// stream to the specified destination, using the specified access method
+// (e.g. file, http, https, udp) and muxer (e.g. ts, ps, mp4, ogg, webm).
+type StandardOutput struct {
+	Access      string
+	Mux         string
+	Destination string
+}
+
+// Validate returns an error if the configuration of the module is not valid.
+func (s *StandardOutput) Validate() error {
+	if err := validateIdentifier("std", "access", s.Access); err != nil {
+		return err
+	}
+	if err := validateIdentifier("std", "mux", s.Mux); err != nil {
+		return err
+	}
+	if s.Destination == "" {
+		return fmt.Errorf("%w: std: no destination specified", ErrInvalidStreamOutput)
+	}
+
+	return nil
+}
+
+func (s *StandardOutput) render() string {
+	opts := &soutOptions{}
+	opts.add("access", s.Access)
+	opts.add("mux", s.Mux)
+	opts.add("dst", s.Destination)
+
+	return opts.render("std")
+}
+
+// FileOutput represents a std stream output module, which saves the stream
+// to the file located at the specified path, using the specified muxer
+// (e.g. ts, ps, mp4, ogg, webm, mkv).
+type FileOutput struct {
+	Path string
+	Mux  string
+}
+
+// Validate returns an error if the configuration of the module is not valid.
+func (f *FileOutput) Validate() error {
+	if f.Path == "" {
+		return fmt.Errorf("%w: file: no path specified", ErrInvalidStreamOutput)
+	}
+
+	return f.std().Validate()
+}
+
+func (f *FileOutput) render() string {
+	return f.std().render()
+}
+
+func (f *FileOutput) std() *StandardOutput {
+	return &StandardOutput{Access: "file", Mux: f.Mux, Destination: f.Path}
+}
+
+// HTTPOutput represents a std stream output module, which serves the stream
+// over HTTP, at the specified address (e.g. `:8080/stream`), using the
+// specified muxer (e.g. ts, ogg, webm). The default muxer is ts.
+type HTTPOutput struct {
+	Address string
+	Mux     string
+}
+
+// Validate returns an error if the configuration of the module is not valid.
+func (h *HTTPOutput) Validate() error {
+	if h.Address == "" {
+		return fmt.Errorf("%w: http: no address specified", ErrInvalidStreamOutput)
+	}
+
+	return h.std().Validate()
+}
+
+func (h *HTTPOutput) render() string {
+	return h.std().render()
+}
+
+func (h *HTTPOutput) std() *StandardOutput {
+	mux := h.Mux
+	if mux == "" {
+		mux = "ts"
+	}
+
+	return &StandardOutput{Access: "http", Mux: mux, Destination: h.Address}
+}
+
+// RTPOutput represents the rtp stream output module, which streams the
+// media over RTP. The SDP field specifies how the session description is
+// announced (e.g. `rtsp://:8554/stream`, `sap`, `file:///tmp/stream.sdp`).
+type RTPOutput struct {
+	Destination string // Destination address.
+	Port        uint   // Destination base port.
+	Mux         string // Optional encapsulation (e.g. ts).
+	SDP         string // Session description announcement.
+	Name        string // Session name.
+}
+
+// Validate returns an error if the configuration of the module is not valid.
+func (r *RTPOutput) Validate() error {
+	if r.Destination == "" && r.SDP == "" {
+		return fmt.Errorf("%w: rtp: no destination or SDP specified", ErrInvalidStreamOutput)
+	}
+	if r.Port > 65535 {
+		return fmt.Errorf("%w: rtp: invalid port %d", ErrInvalidStreamOutput, r.Port)
+	}
+	if r.Mux != "" {
+		return validateIdentifier("rtp", "mux", r.Mux)
+	}
+
+	return nil
+}
+
+func (r *RTPOutput) render() string {
+	opts := &soutOptions{}
+	opts.add("dst", r.Destination)
+	opts.addUint("port", r.Port)
+	opts.add("mux", r.Mux)
+	opts.add("sdp", r.SDP)
+	opts.add("name", r.Name)
+
+	return opts.render("rtp")
+}
+
+// soutOptions is used to render the options of a stream output module.
+type soutOptions struct {
+	opts []string
+}
+
+func (so *soutOptions) add(key, value string) {
+	if value != "" {
+		so.opts = append(so.opts, key+"="+quoteSoutValue(value))
+	}
+}
+
+func (so *soutOptions) addUint(key string, value uint) {
+	if value > 0 {
+		so.opts = append(so.opts, key+"="+strconv.FormatUint(uint64(value), 10))
+	}
+}
+
+func (so *soutOptions) addFloat(key string, value float64) {
+	if value > 0 {
+		so.opts = append(so.opts, key+"="+strconv.FormatFloat(value, 'f', -1, 64))
+	}
+}
+
+func (so *soutOptions) addFlag(key string, value bool) {
+	if value {
+		so.opts = append(so.opts, key)
+	}
+}
+
+func (so *soutOptions) render(module string) string {
+	if len(so.opts) == 0 {
+		return module
+	}
+
+	return module + "{" + strings.Join(so.opts, ",") + "}"
+}
+
+// quoteSoutValue quotes the specified option value, if it contains
+// characters which are special to the stream output chain parser.
+func quoteSoutValue(value string) string {
+	if !strings.ContainsAny(value, ",{}=:'\"\\ ") {
+		return value
+	}
+
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+func validateCodec(key, codec string) error {
+	if codec == "" {
+		return nil
+	}
+	if len(codec) > 4 || !isIdentifier(codec) {
+		return fmt.Errorf("%w: transcode: invalid %s %q", ErrInvalidStreamOutput, key, codec)
+	}
+
+	return nil
+}
+
+func validateIdentifier(module, key, value string) error {
+	if value == "" || !isIdentifier(value) {
+		return fmt.Errorf("%w: %s: invalid %s %q", ErrInvalidStreamOutput, module, key, value)
+	}
+
+	return nil
+}
+
+func isIdentifier(value string) bool {
+	for _, r := range value {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,158 @@
+package vlc
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStreamOutputRender(t *testing.T) {
+	tests := []struct {
+		name string
+		so   *StreamOutput
+		want string
+	}{
+		{
+			name: "display",
+			so:   NewStreamOutput(&Display{}),
+			want: "#display",
+		},
+		{
+			name: "display without audio",
+			so:   NewStreamOutput(&Display{NoAudio: true}),
+			want: "#display{noaudio}",
+		},
+		{
+			name: "transcode to file",
+			so: NewStreamOutput(
+				&Transcode{VideoCodec: "h264", VideoBitrate: 800, Scale: 0.5, AudioCodec: "mp4a", AudioBitrate: 128},
+				&FileOutput{Path: "out.mp4", Mux: "mp4"},
+			),
+			want: "#transcode{vcodec=h264,vb=800,scale=0.5,acodec=mp4a,ab=128}:std{access=file,mux=mp4,dst=out.mp4}",
+		},
+		{
+			name: "transcode all fields",
+			so: NewStreamOutput(&Transcode{
+				VideoCodec: "VP80", VideoBitrate: 2000, Scale: 1, Width: 1280, Height: 720, FPS: 29.97,
+				AudioCodec: "vorb", AudioBitrate: 192, AudioChannels: 2, SampleRate: 48000,
+				SubtitleCodec: "dvbs", SubtitleOverlay: true,
+			}),
+			want: "#transcode{vcodec=VP80,vb=2000,scale=1,width=1280,height=720,fps=29.97," +
+				"acodec=vorb,ab=192,channels=2,samplerate=48000,scodec=dvbs,soverlay}",
+		},
+		{
+			name: "duplicate",
+			so: NewStreamOutput(
+				&Transcode{AudioCodec: "mpga"},
+				&Duplicate{Destinations: []*StreamOutput{
+					NewStreamOutput(&Display{}),
+					NewStreamOutput(&FileOutput{Path: "out.ts", Mux: "ts"}),
+				}},
+			),
+			want: "#transcode{acodec=mpga}:duplicate{dst=display,dst=std{access=file,mux=ts,dst=out.ts}}",
+		},
+		{
+			name: "http",
+			so:   NewStreamOutput(&HTTPOutput{Address: ":8080/stream"}),
+			want: `#std{access=http,mux=ts,dst=":8080/stream"}`,
+		},
+		{
+			name: "rtp",
+			so:   NewStreamOutput(&RTPOutput{SDP: "rtsp://:8554/stream", Name: "Live"}),
+			want: `#rtp{sdp="rtsp://:8554/stream",name=Live}`,
+		},
+		{
+			name: "rtp with port",
+			so:   NewStreamOutput(&RTPOutput{Destination: "239.0.0.1", Port: 5004, Mux: "ts"}),
+			want: "#rtp{dst=239.0.0.1,port=5004,mux=ts}",
+		},
+		{
+			name: "added modules",
+			so:   NewStreamOutput(&Transcode{VideoCodec: "h264"}).Add(&Display{}),
+			want: "#transcode{vcodec=h264}:display",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.so.Render()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+			if s := test.so.String(); s != got {
+				t.Errorf("String: got %s, want %s", s, got)
+			}
+		})
+	}
+}
+
+func TestStreamOutputEscaping(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "out.mp4", want: "out.mp4"},
+		{path: "/tmp/out-1_2.mp4", want: "/tmp/out-1_2.mp4"},
+		{path: "my video.mp4", want: `"my video.mp4"`},
+		{path: "out,1.mp4", want: `"out,1.mp4"`},
+		{path: "out{1}.mp4", want: `"out{1}.mp4"`},
+		{path: "a=b.mp4", want: `"a=b.mp4"`},
+		{path: `C:\videos\out.mp4`, want: `"C:\\videos\\out.mp4"`},
+		{path: `say "hi".mp4`, want: `"say \"hi\".mp4"`},
+		{path: "it's.mp4", want: `"it's.mp4"`},
+		{path: `\"}`, want: `"\\\"}"`},
+		{path: "vidéo.mp4", want: "vidéo.mp4"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			so := NewStreamOutput(&FileOutput{Path: test.path, Mux: "mp4"})
+
+			got, err := so.Render()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := "#std{access=file,mux=mp4,dst=" + test.want + "}"; got != want {
+				t.Errorf("got %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestStreamOutputValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		so   *StreamOutput
+	}{
+		{name: "nil chain"},
+		{name: "empty chain", so: NewStreamOutput()},
+		{name: "nil module", so: NewStreamOutput(nil)},
+		{name: "transcode without codecs", so: NewStreamOutput(&Transcode{VideoBitrate: 800})},
+		{name: "long codec", so: NewStreamOutput(&Transcode{VideoCodec: "h2645"})},
+		{name: "codec injection", so: NewStreamOutput(&Transcode{AudioCodec: "a}:x"})},
+		{name: "negative scale", so: NewStreamOutput(&Transcode{VideoCodec: "h264", Scale: -1})},
+		{name: "negative frame rate", so: NewStreamOutput(&Transcode{VideoCodec: "h264", FPS: -1})},
+		{name: "duplicate without destinations", so: NewStreamOutput(&Duplicate{})},
+		{name: "invalid duplicate destination", so: NewStreamOutput(&Duplicate{Destinations: []*StreamOutput{NewStreamOutput()}})},
+		{name: "display without streams", so: NewStreamOutput(&Display{NoAudio: true, NoVideo: true})},
+		{name: "std without access", so: NewStreamOutput(&StandardOutput{Mux: "ts", Destination: "out.ts"})},
+		{name: "std mux injection", so: NewStreamOutput(&StandardOutput{Access: "file", Mux: "ts,dst=x", Destination: "out.ts"})},
+		{name: "std without destination", so: NewStreamOutput(&StandardOutput{Access: "file", Mux: "ts"})},
+		{name: "file without path", so: NewStreamOutput(&FileOutput{Mux: "mp4"})},
+		{name: "file without mux", so: NewStreamOutput(&FileOutput{Path: "out.mp4"})},
+		{name: "http without address", so: NewStreamOutput(&HTTPOutput{})},
+		{name: "rtp without destination", so: NewStreamOutput(&RTPOutput{Port: 5004})},
+		{name: "rtp invalid port", so: NewStreamOutput(&RTPOutput{Destination: "239.0.0.1", Port: 65536})},
+		{name: "rtp invalid mux", so: NewStreamOutput(&RTPOutput{Destination: "239.0.0.1", Mux: "t s"})},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := test.so.Render(); !errors.Is(err, ErrInvalidStreamOutput) {
+				t.Errorf("expected ErrInvalidStreamOutput, got %v", err)
+			}
+		})
+	}
+}
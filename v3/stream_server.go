@@ -0,0 +1,380 @@
+package vlc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultStreamServerTimeout is the default amount of time a stream server
+// waits for the streaming to start.
+const DefaultStreamServerTimeout = 10 * time.Second
+
+// streamServerPortAttempts is the number of ports a stream server tries to
+// start on, when the port is chosen automatically.
+const streamServerPortAttempts = 3
+
+// StreamProtocol represents the protocol used by a stream server.
+type StreamProtocol uint
+
+// Stream protocols.
+const (
+	// Serve the stream over HTTP.
+	StreamHTTP StreamProtocol = iota
+
+	// Send the stream over RTP, to the specified destination address.
+	StreamRTP
+
+	// Serve the stream over RTP, announcing it through RTSP.
+	StreamRTSP
+)
+
+// Validate returns an error if the stream protocol is not valid.
+func (sp StreamProtocol) Validate() error {
+	if sp > StreamRTSP {
+		return fmt.Errorf("%w: invalid protocol %d", ErrInvalidStreamOutput, sp)
+	}
+
+	return nil
+}
+
+// StreamServerOptions contains the options used to create a stream server.
+type StreamServerOptions struct {
+	// Protocol used to serve the stream.
+	Protocol StreamProtocol
+
+	// Host to bind to or, in the case of RTP, the destination address of
+	// the stream. The default is 127.0.0.1.
+	Host string
+
+	// Port to bind to or, in the case of RTP, the destination port of the
+	// stream. If 0, a free port is chosen each time the server is started.
+	// In the case of RTP, the port is one which is free on the local host,
+	// as the destination cannot be probed.
+	Port uint
+
+	// Path of the stream (e.g. /stream). Only used by the HTTP and RTSP
+	// protocols. The default is /.
+	Path string
+
+	// Container format of the stream (e.g. ts, ogg, webm). The default
+	// is ts.
+	Mux string
+
+	// Optional transcoding settings. If nil, the streams of the media are
+	// served without being transcoded.
+	Transcode *Transcode
+
+	// Restart the media when the end is reached.
+	Loop bool
+}
+
+// StreamServer serves a media over the network, using the HTTP, RTP or
+// RTSP protocols. The media is streamed by a headless player, so the
+// server can be used without video outputs or audio devices.
+type StreamServer struct {
+	opts     StreamServerOptions
+	autoPort bool
+	inst     *Instance
+	player   *Player
+	media    *Media
+	cancel   context.CancelFunc
+	events   <-chan EventInfo
+}
+
+// NewStreamServer returns a new stream server, configured using the
+// specified options.
+//
+//	NOTE: Call the Stop method on the stream server in order to stop
+//	streaming and free the allocated resources.
+func NewStreamServer(opts StreamServerOptions) (*StreamServer, error) {
+	return inst.NewStreamServer(opts)
+}
+
+// NewStreamServer returns a new stream server which uses the instance.
+func (i *Instance) NewStreamServer(opts StreamServerOptions) (*StreamServer, error) {
+	if err := i.assertInit(); err != nil {
+		return nil, err
+	}
+	if err := opts.Protocol.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.Port > 65535 {
+		return nil, fmt.Errorf("%w: invalid port %d", ErrInvalidStreamOutput, opts.Port)
+	}
+
+	// Apply defaults.
+	if opts.Host == "" {
+		opts.Host = "127.0.0.1"
+	}
+	if opts.Mux == "" {
+		opts.Mux = "ts"
+	}
+	if !strings.HasPrefix(opts.Path, "/") {
+		opts.Path = "/" + opts.Path
+	}
+	if opts.Transcode != nil {
+		if err := opts.Transcode.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateIdentifier("stream server", "mux", opts.Mux); err != nil {
+		return nil, err
+	}
+
+	return &StreamServer{opts: opts, autoPort: opts.Port == 0, inst: i}, nil
+}
+
+// Start starts streaming the specified media. The method returns when the
+// player starts playing the media, when an error occurs, or when the
+// provided context is done. If the context has no deadline, the method
+// waits for up to DefaultStreamServerTimeout.
+//
+//	NOTE: If the port of the server is not specified, a free port is chosen
+//	by binding to port 0 and closing the listener before the server binds
+//	to it, as libVLC does not report the address it binds to. If the port
+//	is taken by another process in the meantime, the server is started on
+//	a different port, up to three times. In the case of RTP, the port is
+//	chosen on the local host, so it is not guaranteed to be free on the
+//	destination host.
+func (ss *StreamServer) Start(ctx context.Context, m *Media) error {
+	if ss == nil || ss.inst == nil {
+		return ErrStreamServerNotInitialized
+	}
+	if ss.player != nil {
+		return ErrStreamServerStarted
+	}
+	if err := m.assertInit(); err != nil {
+		return err
+	}
+
+	if !ss.autoPort {
+		return ss.startMedia(ctx, m)
+	}
+
+	// Choose a free port. The port can be taken by another process before
+	// the server binds to it, in which case a different port is tried.
+	var err error
+	for attempt := 0; attempt < streamServerPortAttempts; attempt++ {
+		port, perr := freePort(ss.opts.Protocol, ss.opts.Host)
+		if perr != nil {
+			return perr
+		}
+		ss.opts.Port = port
+
+		if err = ss.startMedia(ctx, m); !errors.Is(err, ErrStreamServerStart) {
+			break
+		}
+	}
+	if err != nil {
+		ss.opts.Port = 0
+	}
+
+	return err
+}
+
+func (ss *StreamServer) startMedia(ctx context.Context, m *Media) error {
+	chain, err := ss.streamOutput().Render()
+	if err != nil {
+		return err
+	}
+
+	// Create a duplicate of the media which uses the stream output chain.
+	media, err := m.Duplicate()
+	if err != nil {
+		return err
+	}
+
+	options := []string{":sout=" + chain}
+	if ss.opts.Loop {
+		options = append(options, ":input-repeat=65535")
+	}
+	if err := media.AddOptions(options...); err != nil {
+		media.Release()
+		return err
+	}
+
+	// Create headless player.
+	player, err := ss.inst.NewPlayer()
+	if err != nil {
+		media.Release()
+		return err
+	}
+	ss.player, ss.media = player, media
+
+	if err := ss.start(ctx); err != nil {
+		ss.Stop()
+		return err
+	}
+
+	return nil
+}
+
+// Stop stops streaming and releases the resources allocated by the server.
+// The server can be started again after being stopped.
+func (ss *StreamServer) Stop() error {
+	if ss == nil || ss.player == nil {
+		return nil
+	}
+
+	ss.player.Stop()
+
+	// Wait for the events to be detached before releasing the player.
+	if ss.cancel != nil {
+		ss.cancel()
+		for range ss.events {
+		}
+	}
+
+	ss.player.Release()
+	ss.media.Release()
+	ss.player, ss.media, ss.cancel, ss.events = nil, nil, nil, nil
+
+	return nil
+}
+
+// IsRunning returns true if the server is streaming.
+func (ss *StreamServer) IsRunning() bool {
+	return ss != nil && ss.player != nil && ss.player.IsPlaying()
+}
+
+// Addr returns the network address of the stream. In the case of the HTTP
+// and RTSP protocols, the server listens on the returned address. In the
+// case of the RTP protocol, the stream is sent to the returned address.
+// If no port was specified, the address is only available after the
+// server is started.
+//
+//	NOTE: The returned address is the one chosen before the server is
+//	started. It is not confirmed by libVLC, which does not report the
+//	address it binds to, so an automatically chosen port is a guess.
+func (ss *StreamServer) Addr() string {
+	if ss == nil {
+		return ""
+	}
+
+	return net.JoinHostPort(ss.opts.Host, strconv.FormatUint(uint64(ss.opts.Port), 10))
+}
+
+// URL returns the URL which can be used in order to consume the stream
+// (e.g. by a Player or by an HTTP client).
+func (ss *StreamServer) URL() string {
+	if ss == nil {
+		return ""
+	}
+
+	switch ss.opts.Protocol {
+	case StreamRTP:
+		return "rtp://@" + ss.Addr()
+	case StreamRTSP:
+		return "rtsp://" + ss.Addr() + ss.opts.Path
+	}
+
+	return "http://" + ss.Addr() + ss.opts.Path
+}
+
+// EventManager returns the event manager of the player used by the server.
+// The server must be started.
+func (ss *StreamServer) EventManager() (*EventManager, error) {
+	if ss == nil || ss.player == nil {
+		return nil, ErrStreamServerNotInitialized
+	}
+
+	return ss.player.EventManager()
+}
+
+func (ss *StreamServer) start(ctx context.Context) error {
+	if err := ss.player.SetMedia(ss.media); err != nil {
+		return err
+	}
+
+	manager, err := ss.player.EventManager()
+	if err != nil {
+		return err
+	}
+
+	subCtx, cancel := context.WithCancel(context.Background())
+	events, err := manager.Subscribe(subCtx, MediaPlayerPlaying, MediaPlayerEncounteredError)
+	if err != nil {
+		cancel()
+		return err
+	}
+	ss.cancel, ss.events = cancel, events
+
+	if err := ss.player.Play(); err != nil {
+		return err
+	}
+
+	// Wait for the streaming to start.
+	if _, ok := ctx.Deadline(); !ok {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, DefaultStreamServerTimeout)
+		defer cancelTimeout()
+	}
+
+	for {
+		select {
+		case info, ok := <-events:
+			if !ok {
+				return fmt.Errorf("%w: player events detached", ErrStreamServerStart)
+			}
+
+			switch info.Event {
+			case MediaPlayerPlaying:
+				return nil
+			case MediaPlayerEncounteredError:
+				// The libVLC error message is thread local, so it is not
+				// available on this goroutine.
+				return fmt.Errorf("%w: player encountered an error (MediaPlayerEncounteredError)", ErrStreamServerStart)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (ss *StreamServer) streamOutput() *StreamOutput {
+	so := NewStreamOutput()
+	if ss.opts.Transcode != nil {
+		so.Add(ss.opts.Transcode)
+	}
+
+	opts := ss.opts
+	switch opts.Protocol {
+	case StreamRTP:
+		so.Add(&RTPOutput{Destination: opts.Host, Port: opts.Port, Mux: opts.Mux})
+	case StreamRTSP:
+		so.Add(&RTPOutput{SDP: "rtsp://" + ss.Addr() + opts.Path, Mux: opts.Mux})
+	default:
+		so.Add(&HTTPOutput{Address: ss.Addr() + opts.Path, Mux: opts.Mux})
+	}
+
+	return so
+}
+
+// freePort returns a port which is available on the specified host. In the
+// case of RTP, the host is the destination of the stream, which cannot be
+// bound to, so a port which is available on the local host is returned.
+func freePort(protocol StreamProtocol, host string) (uint, error) {
+	// RTP streams are sent over UDP.
+	if protocol == StreamRTP {
+		conn, err := net.ListenPacket("udp", ":0")
+		if err != nil {
+			return 0, err
+		}
+		defer conn.Close()
+
+		return uint(conn.LocalAddr().(*net.UDPAddr).Port), nil
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, "0"))
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+
+	return uint(listener.Addr().(*net.TCPAddr).Port), nil
+}
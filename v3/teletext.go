@@ -0,0 +1,99 @@
+package vlc
+
+// #cgo LDFLAGS: -lvlc
+// #include <vlc/vlc.h>
+import "C"
+
+// TeletextKey represents a teletext navigation key. The color keys
+// navigate to the pages linked by the colored links (Fastext) of the
+// current page, while the index key navigates to the index page.
+type TeletextKey int
+
+// Teletext keys.
+const (
+	TeletextKeyRed    TeletextKey = 'r' << 16
+	TeletextKeyGreen  TeletextKey = 'g' << 16
+	TeletextKeyYellow TeletextKey = 'y' << 16
+	TeletextKeyBlue   TeletextKey = 'b' << 16
+	TeletextKeyIndex  TeletextKey = 'i' << 16
+)
+
+// Validate returns an error if the teletext key is not valid.
+func (tk TeletextKey) Validate() error {
+	switch tk {
+	case TeletextKeyRed, TeletextKeyGreen, TeletextKeyYellow, TeletextKeyBlue, TeletextKeyIndex:
+		return nil
+	}
+
+	return ErrInvalidTeletextKey
+}
+
+// TeletextPage returns the teletext page requested for the player.
+//
+//	NOTE: The method returns 0 if teletext is disabled. The default page
+//	is 100 (the index page).
+func (p *Player) TeletextPage() (int, error) {
+	if err := p.assertInit(); err != nil {
+		return 0, err
+	}
+
+	return int(C.libvlc_video_get_teletext(p.player)), nil
+}
+
+// SetTeletextPage requests the specified teletext page to be displayed
+// (e.g. 100 for the index page or 888 for subtitles). The page must be in
+// the [1, 999] interval. Pass in 0 in order to disable teletext.
+//
+//	NOTE: The teletext page is only displayed if the current media contains
+//	a teletext track (e.g. DVB recordings). Teletext subtitles can also be
+//	displayed by selecting the teletext subtitle track of the player.
+func (p *Player) SetTeletextPage(page int) error {
+	if err := p.assertInit(); err != nil {
+		return err
+	}
+	if page < 0 || page > 999 {
+		return ErrInvalidTeletextPage
+	}
+
+	C.libvlc_video_set_teletext(p.player, C.int(page))
+	return getError()
+}
+
+// SendTeletextKey sends the specified navigation key to the teletext
+// decoder, which navigates to the page associated with the key.
+func (p *Player) SendTeletextKey(key TeletextKey) error {
+	if err := p.assertInit(); err != nil {
+		return err
+	}
+	if err := key.Validate(); err != nil {
+		return err
+	}
+
+	C.libvlc_video_set_teletext(p.player, C.int(key))
+	return getError()
+}
+
+// TeletextRed navigates to the page linked by the red teletext key.
+func (p *Player) TeletextRed() error {
+	return p.SendTeletextKey(TeletextKeyRed)
+}
+
+// TeletextGreen navigates to the page linked by the green teletext key.
+func (p *Player) TeletextGreen() error {
+	return p.SendTeletextKey(TeletextKeyGreen)
+}
+
+// TeletextYellow navigates to the page linked by the yellow teletext key.
+func (p *Player) TeletextYellow() error {
+	return p.SendTeletextKey(TeletextKeyYellow)
+}
+
+// TeletextBlue navigates to the page linked by the blue teletext key.
+func (p *Player) TeletextBlue() error {
+	return p.SendTeletextKey(TeletextKeyBlue)
+}
+
+// TeletextIndex navigates to the teletext index page.
+func (p *Player) TeletextIndex() error {
+	return p.SendTeletextKey(TeletextKeyIndex)
+}
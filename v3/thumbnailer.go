@@ -0,0 +1,317 @@
+package vlc
+
+import (
+	"image"
+	"time"
+)
+
+// DefaultThumbnailTimeout is the default amount of time a thumbnailer waits
+// for a video frame to be decoded.
+const DefaultThumbnailTimeout = 10 * time.Second
+
+// thumbnailTolerance represents the maximum difference between the requested
+// time offset of a thumbnail and the time at which a frame is displayed, in
+// order for the frame to be used as a thumbnail.
+const thumbnailTolerance = 1500 * time.Millisecond
+
+// Thumbnailer extracts still images from media files, at specified time
+// offsets or positions. The thumbnailer decodes the media using a muted
+// player, which delivers the video frames to memory, so it does not
+// require a video output or an audio device. This makes it suitable for
+// headless environments (e.g. instances created using the `--vout=dummy`
+// and `--aout=dummy` arguments).
+type Thumbnailer struct {
+	player  *Player
+	sink    *thumbnailSink
+	timeout time.Duration
+}
+
+// NewThumbnailer returns a new thumbnailer which produces images of the
+// specified size. If the specified width is 0, the width of the images is
+// calculated based on the specified height, in order to preserve the aspect
+// ratio of the video. Similarly, if the specified height is 0, the height of
+// the images is calculated based on the specified width. If both values are
+// 0, the original dimensions of the video are used. The timeout represents
+// the maximum amount of time to wait for each image. If it is 0, the
+// DefaultThumbnailTimeout value is used.
+//
+//	NOTE: Call the Release method on the thumbnailer in order to free the
+//	allocated resources.
+func NewThumbnailer(width, height uint, timeout time.Duration) (*Thumbnailer, error) {
+	return inst.NewThumbnailer(width, height, timeout)
+}
+
+// NewThumbnailer returns a new thumbnailer which uses the instance.
+// See the package level NewThumbnailer function for more details.
+func (i *Instance) NewThumbnailer(width, height uint, timeout time.Duration) (*Thumbnailer, error) {
+	if timeout <= 0 {
+		timeout = DefaultThumbnailTimeout
+	}
+
+	player, err := i.NewPlayer()
+	if err != nil {
+		return nil, err
+	}
+
+	// Deliver video frames to memory and discard audio samples.
+	sink := &thumbnailSink{
+		width:  width,
+		height: height,
+		frames: make(chan *VideoFrame, 1),
+	}
+	if err := player.SetVideoSink(sink); err != nil {
+		player.Release()
+		return nil, err
+	}
+	if err := player.SetAudioSink(discardAudioSink{}); err != nil {
+		player.Release()
+		return nil, err
+	}
+
+	return &Thumbnailer{
+		player:  player,
+		sink:    sink,
+		timeout: timeout,
+	}, nil
+}
+
+// Release destroys the thumbnailer.
+func (t *Thumbnailer) Release() error {
+	if err := t.assertInit(); err != nil {
+		return nil
+	}
+
+	t.player.Stop()
+	t.player.Release()
+	t.sink.drain()
+	t.player = nil
+
+	return nil
+}
+
+// AtTimes returns images of the specified media, taken at the provided
+// time offsets. The images are returned in the order of the offsets.
+func (t *Thumbnailer) AtTimes(m *Media, offsets ...time.Duration) ([]image.Image, error) {
+	return t.thumbnails(m, len(offsets), func(idx int, length time.Duration) (time.Duration, error) {
+		offset := offsets[idx]
+		if offset < 0 || (length > 0 && offset > length) {
+			return 0, ErrInvalid
+		}
+
+		return offset, nil
+	})
+}
+
+// AtPositions returns images of the specified media, taken at the provided
+// positions. The positions are expressed as percentages of the media length,
+// in the [0.0, 1.0] interval. The images are returned in the order of the
+// positions.
+func (t *Thumbnailer) AtPositions(m *Media, positions ...float32) ([]image.Image, error) {
+	return t.thumbnails(m, len(positions), func(idx int, length time.Duration) (time.Duration, error) {
+		pos := positions[idx]
+		if pos < 0 || pos > 1 || length <= 0 {
+			return 0, ErrInvalid
+		}
+
+		return time.Duration(float64(pos) * float64(length)), nil
+	})
+}
+
+func (t *Thumbnailer) thumbnails(m *Media, count int,
+	target func(int, time.Duration) (time.Duration, error)) ([]image.Image, error) {
+	if err := t.assertInit(); err != nil {
+		return nil, err
+	}
+	if err := m.assertInit(); err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, nil
+	}
+
+	// Start playback and wait for the video output to start.
+	if err := t.player.SetMedia(m); err != nil {
+		return nil, err
+	}
+	if err := t.player.Play(); err != nil {
+		return nil, err
+	}
+	defer func() {
+		t.player.Stop()
+		t.sink.drain()
+	}()
+
+	frame, err := t.sink.next(t.timeout)
+	if err != nil {
+		return nil, err
+	}
+	frame.Release()
+
+	length, err := t.player.MediaLength()
+	if err != nil {
+		return nil, err
+	}
+
+	// Seek to the requested offsets and capture the displayed frames.
+	images := make([]image.Image, 0, count)
+	for i := 0; i < count; i++ {
+		offset, err := target(i, time.Duration(length)*time.Millisecond)
+		if err != nil {
+			return nil, err
+		}
+
+		img, err := t.capture(offset)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, img)
+	}
+
+	return images, nil
+}
+
+func (t *Thumbnailer) capture(offset time.Duration) (image.Image, error) {
+	t.sink.drain()
+	if err := t.player.SetMediaTime(int(offset / time.Millisecond)); err != nil {
+		return nil, err
+	}
+
+	// Frames decoded before the seek may still be displayed. Wait for a
+	// frame displayed close to the requested offset, falling back to the
+	// most recent frame if none is received before the timeout.
+	var last *VideoFrame
+	defer func() {
+		last.Release()
+	}()
+
+	deadline := time.Now().Add(t.timeout)
+	for {
+		frame, err := t.sink.next(time.Until(deadline))
+		if err != nil {
+			if last == nil {
+				return nil, err
+			}
+			return frameImage(last)
+		}
+
+		last.Release()
+		last = frame
+
+		// Frames do not carry timestamps, so the playback time of the
+		// player is used instead.
+		current, err := t.player.MediaTime()
+		if err != nil {
+			continue
+		}
+		if delta := time.Duration(current)*time.Millisecond - offset; delta >= -thumbnailTolerance && delta <= thumbnailTolerance {
+			return frameImage(frame)
+		}
+	}
+}
+
+func frameImage(frame *VideoFrame) (image.Image, error) {
+	img, err := frame.RGBA()
+	if err != nil {
+		return nil, err
+	}
+
+	return img, nil
+}
+
+func (t *Thumbnailer) assertInit() error {
+	if t == nil || t.player == nil {
+		return ErrThumbnailerNotInitialized
+	}
+
+	return nil
+}
+
+// thumbnailSink is a video sink which delivers the most recent video
+// frame, scaled to the dimensions of the thumbnails.
+type thumbnailSink struct {
+	width  uint
+	height uint
+	frames chan *VideoFrame
+}
+
+func (ts *thumbnailSink) SetupVideo(format *VideoFormat) error {
+	width, height := ts.width, ts.height
+	switch {
+	case width == 0 && height == 0:
+		width, height = format.Width, format.Height
+	case width == 0 && format.Height > 0:
+		width = format.Width * height / format.Height
+	case height == 0 && format.Width > 0:
+		height = format.Height * width / format.Width
+	}
+
+	format.Chroma = VideoChromaRV32
+	format.Width, format.Height = width, height
+	return nil
+}
+
+func (ts *thumbnailSink) RenderVideo(frame *VideoFrame) {
+	for {
+		select {
+		case ts.frames <- frame:
+			return
+		default:
+		}
+
+		// Replace the pending frame with the most recent one.
+		select {
+		case old := <-ts.frames:
+			old.Release()
+		default:
+		}
+	}
+}
+
+func (ts *thumbnailSink) CleanupVideo() {
+}
+
+func (ts *thumbnailSink) next(timeout time.Duration) (*VideoFrame, error) {
+	if timeout <= 0 {
+		return nil, ErrThumbnailTimeout
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case frame := <-ts.frames:
+		return frame, nil
+	case <-timer.C:
+		return nil, ErrThumbnailTimeout
+	}
+}
+
+func (ts *thumbnailSink) drain() {
+	for {
+		select {
+		case frame := <-ts.frames:
+			frame.Release()
+		default:
+			return
+		}
+	}
+}
+
+// discardAudioSink is an audio sink which discards all samples.
+type discardAudioSink struct{}
+
+func (discardAudioSink) SetupAudio(format *AudioFormat) error {
+	format.SampleFormat = AudioSampleFormatS16N
+	return nil
+}
+
+func (discardAudioSink) PlayAudio(buf *AudioBuffer) {
+	buf.Release()
+}
+
+func (discardAudioSink) PauseAudio(pts time.Duration)             {}
+func (discardAudioSink) ResumeAudio(pts time.Duration)            {}
+func (discardAudioSink) FlushAudio(pts time.Duration)             {}
+func (discardAudioSink) DrainAudio()                              {}
+func (discardAudioSink) SetAudioVolume(volume float32, mute bool) {}
+func (discardAudioSink) CleanupAudio()                            {}
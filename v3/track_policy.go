@@ -0,0 +1,332 @@
+package vlc
+
+import (
+	"strings"
+	"sync"
+)
+
+// TrackPolicy contains the rules used to select the audio and subtitle
+// tracks of a player automatically, based on the preferred languages.
+// Languages can be specified using ISO 639-1 codes (e.g. en), ISO 639-2
+// codes (e.g. eng, fre) or English language names (e.g. english).
+type TrackPolicy struct {
+	// Preferred audio languages, in order of preference. If none of the
+	// audio tracks matches, the audio track selected by libVLC is kept.
+	AudioLanguages []string
+
+	// Preferred subtitle languages, in order of preference. If none of the
+	// subtitle tracks matches, the subtitle track selected by libVLC
+	// is kept.
+	SubtitleLanguages []string
+
+	// Prefer audio description tracks (e.g. for visually impaired viewers).
+	// Otherwise, audio description tracks are only selected if no other
+	// track matches the preferred languages.
+	PreferAudioDescription bool
+
+	// Prefer commentary tracks. Otherwise, commentary tracks are only
+	// selected if no other track matches the preferred languages.
+	PreferCommentary bool
+
+	// Only select subtitles when the language of the selected audio track
+	// is foreign, i.e. it is not one of the preferred subtitle languages.
+	// Otherwise, subtitles are disabled.
+	SubtitlesOnlyForeign bool
+
+	// Allow forced subtitles, which only cover foreign dialogue or
+	// on-screen text, to be selected.
+	ForcedSubtitles bool
+}
+
+// SetTrackPolicy sets the policy used to select the audio and subtitle
+// tracks of the player. The policy is applied automatically each time
+// tracks are added to the current media of the player, signaled by
+// MediaPlayerESAdded events. Pass in nil in order to remove the policy.
+// The policy belongs to the underlying libVLC player, so it is shared by
+// all the Player values which wrap it (e.g. the player returned by the
+// ListPlayer.Player method).
+//
+//	NOTE: The tracks are selected asynchronously, by a separate goroutine,
+//	shortly after the tracks are added. The selections made by the policy
+//	are serialized with the ApplyTrackPolicy method, but not with the
+//	other track setters of the player (e.g. SetAudioTrack). While a policy
+//	is attached, tracks selected manually as tracks are being added may be
+//	overridden by the policy. In order to select tracks manually, either
+//	wait for the tracks of the media to be added, or remove the policy.
+func (p *Player) SetTrackPolicy(policy *TrackPolicy) error {
+	if err := p.assertInit(); err != nil {
+		return err
+	}
+	p.discardTrackPolicy()
+
+	if policy == nil {
+		return nil
+	}
+
+	manager, err := p.EventManager()
+	if err != nil {
+		return err
+	}
+
+	state := &trackPolicyState{
+		policy:   *policy,
+		requests: make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+
+	eventID, err := manager.Attach(MediaPlayerESAdded, func(Event, interface{}) {
+		state.notify()
+	}, nil)
+	if err != nil {
+		return err
+	}
+	state.eventIDs = append(state.eventIDs, eventID)
+
+	// The tracks cannot be selected from within libVLC event callbacks,
+	// so the policy is applied by a separate goroutine. The goroutine uses
+	// its own wrapper of the player, as the wrapper provided by the caller
+	// can be released or modified in the meantime.
+	player := &Player{player: p.player, inst: p.inst}
+	go func() {
+		defer close(state.done)
+		for range state.requests {
+			player.ApplyTrackPolicy(&state.policy)
+		}
+	}()
+
+	// Replace the policy attached concurrently, if any.
+	playerState := p.state()
+	playerState.Lock()
+	previous := playerState.trackPolicy
+	playerState.trackPolicy = state
+	playerState.Unlock()
+
+	if previous != nil {
+		previous.discard(manager)
+	}
+	return nil
+}
+
+// ApplyTrackPolicy selects the audio and subtitle tracks of the current
+// media of the player, using the specified policy. Concurrent calls, along
+// with the selections made by the policy set using SetTrackPolicy, are
+// serialized.
+func (p *Player) ApplyTrackPolicy(policy *TrackPolicy) error {
+	if err := p.assertInit(); err != nil {
+		return err
+	}
+	if policy == nil {
+		return ErrInvalidTrackPolicy
+	}
+
+	playerState := p.state()
+	playerState.tracksMu.Lock()
+	defer playerState.tracksMu.Unlock()
+
+	// Get the languages of the tracks from the current media.
+	languages := map[int]string{}
+	if m, err := p.Media(); err == nil && m != nil {
+		if tracks, err := m.Tracks(); err == nil {
+			for _, track := range tracks {
+				if track.Language != "" {
+					languages[track.ID] = track.Language
+				}
+			}
+		}
+	}
+
+	// Select audio track.
+	audioTracks, err := p.AudioTrackDescriptors()
+	if err != nil {
+		return err
+	}
+
+	audioID, err := p.AudioTrackID()
+	if err != nil {
+		return err
+	}
+	if id, ok := policy.selectAudioTrack(audioTracks, languages); ok && id != audioID {
+		if err := p.SetAudioTrack(id); err != nil {
+			return err
+		}
+		audioID = id
+	}
+
+	// Select subtitle track.
+	subtitleTracks, err := p.SubtitleTrackDescriptors()
+	if err != nil {
+		return err
+	}
+
+	subtitleID, err := p.SubtitleTrackID()
+	if err != nil {
+		return err
+	}
+
+	audioLanguage := trackLanguage(audioID, audioTracks, languages)
+	if policy.SubtitlesOnlyForeign && languageRank(audioLanguage, policy.SubtitleLanguages) >= 0 {
+		if subtitleID != -1 {
+			return p.SetSubtitleTrack(-1)
+		}
+		return nil
+	}
+	if id, ok := policy.selectSubtitleTrack(subtitleTracks, languages); ok && id != subtitleID {
+		return p.SetSubtitleTrack(id)
+	}
+
+	return nil
+}
+
+func (tp *TrackPolicy) selectAudioTrack(tracks []*MediaTrackDescriptor, languages map[int]string) (int, bool) {
+	bestID, bestRank, bestPenalty := -1, len(tp.AudioLanguages), 0
+	for _, track := range tracks {
+		if track.ID == -1 {
+			continue
+		}
+
+		rank := languageRank(trackLanguage(track.ID, tracks, languages), tp.AudioLanguages)
+		if rank < 0 {
+			rank = len(tp.AudioLanguages)
+		}
+
+		// Tracks which are not preferred are penalized.
+		var penalty int
+		description := strings.ToLower(track.Description)
+		if isAudioDescription(description) != tp.PreferAudioDescription {
+			penalty++
+		}
+		if strings.Contains(description, "commentary") != tp.PreferCommentary {
+			penalty++
+		}
+
+		if bestID == -1 || rank < bestRank || (rank == bestRank && penalty < bestPenalty) {
+			bestID, bestRank, bestPenalty = track.ID, rank, penalty
+		}
+	}
+
+	// Keep the current track if no track matches the policy.
+	if bestID == -1 {
+		return -1, false
+	}
+	if bestRank == len(tp.AudioLanguages) {
+		preferKind := tp.PreferAudioDescription || tp.PreferCommentary
+		if !preferKind || bestPenalty > 0 {
+			return -1, false
+		}
+	}
+
+	return bestID, true
+}
+
+func (tp *TrackPolicy) selectSubtitleTrack(tracks []*MediaTrackDescriptor, languages map[int]string) (int, bool) {
+	bestID, bestRank := -1, len(tp.SubtitleLanguages)
+	for _, track := range tracks {
+		if track.ID == -1 {
+			continue
+		}
+		if !tp.ForcedSubtitles && strings.Contains(strings.ToLower(track.Description), "forced") {
+			continue
+		}
+
+		rank := languageRank(trackLanguage(track.ID, tracks, languages), tp.SubtitleLanguages)
+		if rank >= 0 && rank < bestRank {
+			bestID, bestRank = track.ID, rank
+		}
+	}
+
+	return bestID, bestID != -1
+}
+
+func (p *Player) discardTrackPolicy() {
+	playerState := p.state()
+	playerState.Lock()
+	state := playerState.trackPolicy
+	playerState.trackPolicy = nil
+	playerState.Unlock()
+
+	if state == nil {
+		return
+	}
+
+	manager, _ := p.EventManager()
+	state.discard(manager)
+}
+
+// trackPolicyState contains the state of a track policy applied
+// automatically by a player.
+type trackPolicyState struct {
+	policy   TrackPolicy
+	eventIDs []EventID
+
+	mu       sync.Mutex
+	closed   bool
+	requests chan struct{}
+	done     chan struct{}
+}
+
+func (s *trackPolicyState) notify() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	// Coalesce the requests received while the policy is applied.
+	select {
+	case s.requests <- struct{}{}:
+	default:
+	}
+}
+
+// discard detaches the events of the policy and waits for the goroutine
+// applying it to return. The manager can be nil.
+func (s *trackPolicyState) discard(manager *EventManager) {
+	if manager != nil {
+		manager.Detach(s.eventIDs...)
+	}
+	s.stop()
+}
+
+func (s *trackPolicyState) stop() {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.requests)
+	}
+	s.mu.Unlock()
+
+	<-s.done
+}
+
+// trackLanguage returns the language of the track with the specified ID.
+// If the language is not available in the media tracks, the language is
+// detected from the description of the track (e.g. "Track 1 - [English]").
+func trackLanguage(id int, tracks []*MediaTrackDescriptor, languages map[int]string) string {
+	if language, ok := languages[id]; ok {
+		return language
+	}
+
+	for _, track := range tracks {
+		if track.ID != id {
+			continue
+		}
+
+		tokens := strings.FieldsFunc(track.Description, func(r rune) bool {
+			return r == ' ' || r == '-' || r == '[' || r == ']' || r == '(' || r == ')' || r == ','
+		})
+		for _, token := range tokens {
+			if len(token) > 2 && isLanguage(token) {
+				return token
+			}
+		}
+	}
+
+	return ""
+}
+
+func isAudioDescription(description string) bool {
+	return strings.Contains(description, "audio description") ||
+		strings.Contains(description, "described") ||
+		strings.Contains(description, "visually impaired")
+}
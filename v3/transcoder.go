@@ -0,0 +1,228 @@
+package vlc
+
+import (
+	"context"
+	"time"
+)
+
+// TranscodePreset contains the settings used to transcode a media.
+type TranscodePreset struct {
+	// Name of the preset.
+	Name string
+
+	// Transcoding settings.
+	Transcode Transcode
+
+	// Container format of the output (e.g. mp4, webm, ogg).
+	Mux string
+
+	// Conventional file extension of the output (e.g. ".mp4").
+	Extension string
+
+	// Discard the video streams of the media.
+	NoVideo bool
+}
+
+// Validate returns an error if the preset is not valid.
+func (tp *TranscodePreset) Validate() error {
+	if tp == nil {
+		return ErrInvalidTranscodePreset
+	}
+	if err := tp.Transcode.Validate(); err != nil {
+		return err
+	}
+
+	return validateIdentifier("preset", "mux", tp.Mux)
+}
+
+// PresetH264AACMP4 returns a preset which produces MP4 files containing
+// H.264 video and AAC audio.
+func PresetH264AACMP4() *TranscodePreset {
+	return &TranscodePreset{
+		Name: "h264-aac-mp4",
+		Transcode: Transcode{
+			VideoCodec:    "h264",
+			VideoBitrate:  2000,
+			AudioCodec:    "mp4a",
+			AudioBitrate:  128,
+			AudioChannels: 2,
+		},
+		Mux:       "mp4",
+		Extension: ".mp4",
+	}
+}
+
+// PresetVP8VorbisWebM returns a preset which produces WebM files containing
+// VP8 video and Vorbis audio.
+func PresetVP8VorbisWebM() *TranscodePreset {
+	return &TranscodePreset{
+		Name: "vp8-vorbis-webm",
+		Transcode: Transcode{
+			VideoCodec:    "VP80",
+			VideoBitrate:  2000,
+			AudioCodec:    "vorb",
+			AudioBitrate:  128,
+			AudioChannels: 2,
+		},
+		Mux:       "webm",
+		Extension: ".webm",
+	}
+}
+
+// PresetOpusAudio returns a preset which produces Ogg files containing
+// Opus audio.
+func PresetOpusAudio() *TranscodePreset {
+	return &TranscodePreset{
+		Name: "opus-audio",
+		Transcode: Transcode{
+			AudioCodec:    "opus",
+			AudioBitrate:  96,
+			AudioChannels: 2,
+			SampleRate:    48000,
+		},
+		Mux:       "ogg",
+		Extension: ".opus",
+		NoVideo:   true,
+	}
+}
+
+// PresetMP3Audio returns a preset which produces MP3 files.
+func PresetMP3Audio() *TranscodePreset {
+	return &TranscodePreset{
+		Name: "mp3-audio",
+		Transcode: Transcode{
+			AudioCodec:    "mpga",
+			AudioBitrate:  192,
+			AudioChannels: 2,
+			SampleRate:    44100,
+		},
+		Mux:       "dummy",
+		Extension: ".mp3",
+		NoVideo:   true,
+	}
+}
+
+// TranscodePresets returns the list of available transcode presets.
+// Each call returns new presets, which can be modified by the caller.
+func TranscodePresets() []*TranscodePreset {
+	return []*TranscodePreset{
+		PresetH264AACMP4(),
+		PresetVP8VorbisWebM(),
+		PresetOpusAudio(),
+		PresetMP3Audio(),
+	}
+}
+
+// TranscodePresetByName returns a new transcode preset with the specified
+// name.
+func TranscodePresetByName(name string) (*TranscodePreset, error) {
+	for _, preset := range TranscodePresets() {
+		if preset.Name == name {
+			return preset, nil
+		}
+	}
+
+	return nil, ErrInvalidTranscodePreset
+}
+
+// TranscodeProgress contains information about the progress of a
+// transcoding job.
+type TranscodeProgress struct {
+	// Percentage of the media transcoded so far, in the [0.0, 1.0] interval.
+	Progress float32
+
+	// Time elapsed since the job was started.
+	Elapsed time.Duration
+
+	// Estimated amount of time until the job is finished. The value is 0
+	// until an estimation can be made.
+	ETA time.Duration
+}
+
+// Transcoder converts media files using a transcode preset. The transcoding
+// jobs are performed by headless players, which neither display the video
+// nor play the audio, so the transcoder can be used on servers without
+// video outputs or audio devices.
+type Transcoder struct {
+	// Preset used by the transcoding jobs.
+	Preset *TranscodePreset
+
+	// Optional progress callback, which is invoked periodically while a
+	// transcoding job is running.
+	Progress func(progress TranscodeProgress)
+}
+
+// NewTranscoder returns a new transcoder which uses the preset with the
+// specified name. Use the TranscodePresets function in order to obtain
+// the list of available presets.
+func NewTranscoder(preset string) (*Transcoder, error) {
+	p, err := TranscodePresetByName(preset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Transcoder{Preset: p}, nil
+}
+
+// Transcode converts the specified media and saves the result at the
+// provided output path. The media is not modified, as the job is performed
+// using a duplicate of it. The method returns the statistics of the media,
+// collected at the end of the job, once the job is finished. The job is
+// aborted when the provided context is done. The returned error is an
+// *ExportError if the job does not succeed, including when the statistics
+// cannot be retrieved, so the returned statistics are never nil on success.
+func (t *Transcoder) Transcode(ctx context.Context, m *Media, outputPath string) (*MediaStats, error) {
+	if t == nil {
+		return nil, ErrInvalid
+	}
+	if err := m.assertInit(); err != nil {
+		return nil, err
+	}
+	if err := t.Preset.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Create stream output chain.
+	so := NewStreamOutput(
+		&t.Preset.Transcode,
+		&FileOutput{Path: outputPath, Mux: t.Preset.Mux},
+	)
+
+	chain, err := so.Render()
+	if err != nil {
+		return nil, err
+	}
+
+	options := []string{":sout=" + chain}
+	if t.Preset.NoVideo {
+		options = append(options, ":no-sout-video")
+	}
+
+	// Run transcoding job.
+	start := time.Now()
+	job := &exportJob{
+		media:   m,
+		options: options,
+		progress: func(pos float32, _ time.Duration) {
+			if t.Progress == nil {
+				return
+			}
+
+			progress := TranscodeProgress{
+				Progress: clampProgress(float64(pos)),
+				Elapsed:  time.Since(start),
+			}
+			if progress.Progress > 0 {
+				remaining := float64(progress.Elapsed) * float64(1-progress.Progress) / float64(progress.Progress)
+				progress.ETA = time.Duration(remaining)
+			}
+
+			t.Progress(progress)
+		},
+	}
+	if err := job.run(ctx); err != nil {
+		return nil, &ExportError{Op: "transcode", Path: outputPath, Err: err}
+	}
+
+	return job.stats, nil
+}
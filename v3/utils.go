@@ -60,3 +60,17 @@ func urlToPath(mrl string) (string, error) {
 
 	return path, nil
 }
+
+func pathToURL(path string) (string, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	return (&url.URL{Scheme: "file", Path: path}).String(), nil
+}
@@ -0,0 +1,580 @@
+package vlc
+
+/*
+#cgo LDFLAGS: -lvlc
+#include <vlc/vlc.h>
+#include <stdlib.h>
+#include <string.h>
+
+extern void* videoLockCB(void* opaque, void** planes);
+extern void videoUnlockCB(void* opaque, void* picture, void** planes);
+extern void videoDisplayCB(void* opaque, void* picture);
+extern unsigned videoFormatCB(void** opaque, char* chroma, unsigned* width, unsigned* height, unsigned* pitches, unsigned* lines);
+extern void videoCleanupCB(void* opaque);
+
+static inline void videoUnlock(void* opaque, void* picture, void* const* planes) {
+	videoUnlockCB(opaque, picture, (void**)planes);
+}
+
+static inline void videoSetCallbacks(libvlc_media_player_t* player, void* opaque) {
+	libvlc_video_set_callbacks(player, videoLockCB, videoUnlock, videoDisplayCB, opaque);
+	libvlc_video_set_format_callbacks(player, videoFormatCB, videoCleanupCB);
+}
+*/
+import "C"
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"sync"
+	"unsafe"
+)
+
+// VideoChroma identifies the pixel format of the decoded video frames
+// delivered to a video sink.
+type VideoChroma string
+
+// Video chromas.
+const (
+	// Packed 32-bit RGB. Each pixel is stored as 4 bytes, in B, G, R, X
+	// order, on little-endian systems.
+	VideoChromaRV32 VideoChroma = "RV32"
+
+	// Packed 24-bit RGB. Each pixel is stored as 3 bytes, in B, G, R order,
+	// on little-endian systems.
+	VideoChromaRV24 VideoChroma = "RV24"
+
+	// Planar YUV 4:2:0. The frames contain a Y plane, followed by the U and
+	// V planes, which are subsampled by a factor of 2 on both axes.
+	VideoChromaI420 VideoChroma = "I420"
+)
+
+// Validate returns an error if the chroma is not supported by video sinks.
+func (c VideoChroma) Validate() error {
+	switch c {
+	case VideoChromaRV32, VideoChromaRV24, VideoChromaI420:
+		return nil
+	}
+
+	return ErrVideoFormatUnsupported
+}
+
+// VideoFormat contains information about the format of the video frames
+// delivered to a video sink.
+type VideoFormat struct {
+	Chroma VideoChroma // Pixel format of the frames.
+	Width  uint        // Width of the frames, in pixels.
+	Height uint        // Height of the frames, in pixels.
+
+	// Number of bytes per line of each plane of the frames.
+	// Set by the player once the format is negotiated.
+	Pitches []uint
+
+	// Number of lines of each plane of the frames.
+	// Set by the player once the format is negotiated.
+	Lines []uint
+}
+
+// FrameSize returns the number of bytes occupied by a frame.
+func (vf *VideoFormat) FrameSize() int {
+	var size int
+	for i := range vf.Pitches {
+		if i < len(vf.Lines) {
+			size += int(vf.Pitches[i] * vf.Lines[i])
+		}
+	}
+
+	return size
+}
+
+// VideoFrame represents a decoded video frame delivered to a video sink.
+//
+//	NOTE: libVLC does not expose the presentation timestamps of the frames
+//	through the video callbacks, and the player cannot be queried from
+//	within them. Instead, the Clock field contains the time at which the
+//	frame was displayed, as determined by the playback clock. The clocks
+//	of consecutive frames can be used to measure the interval between them.
+type VideoFrame struct {
+	// Format of the frame.
+	Format VideoFormat
+
+	// Value of the libVLC clock when the frame was displayed, in
+	// microseconds. The libVLC clock is monotonic and its origin is
+	// arbitrary.
+	Clock int64
+
+	// Pixel data of each plane of the frame. Each plane contains
+	// Format.Lines[i] lines of Format.Pitches[i] bytes.
+	Planes [][]byte
+
+	buf  *[]byte
+	pool *sync.Pool
+}
+
+// Image returns an image backed by the pixel data of the frame. The image
+// is only valid until the frame is released.
+//
+//	NOTE: RV32 and RV24 frames are returned as images with an RGBA color
+//	model, while I420 frames are returned as *image.YCbCr.
+func (vf *VideoFrame) Image() (image.Image, error) {
+	if vf == nil || len(vf.Planes) == 0 {
+		return nil, ErrInvalid
+	}
+
+	width, height := int(vf.Format.Width), int(vf.Format.Height)
+	rect := image.Rect(0, 0, width, height)
+
+	switch vf.Format.Chroma {
+	case VideoChromaRV32:
+		return &bgrImage{pix: vf.Planes[0], stride: int(vf.Format.Pitches[0]), bpp: 4, rect: rect}, nil
+	case VideoChromaRV24:
+		return &bgrImage{pix: vf.Planes[0], stride: int(vf.Format.Pitches[0]), bpp: 3, rect: rect}, nil
+	case VideoChromaI420:
+		if len(vf.Planes) < 3 {
+			return nil, ErrInvalid
+		}
+
+		return &image.YCbCr{
+			Y:              vf.Planes[0],
+			Cb:             vf.Planes[1],
+			Cr:             vf.Planes[2],
+			YStride:        int(vf.Format.Pitches[0]),
+			CStride:        int(vf.Format.Pitches[1]),
+			SubsampleRatio: image.YCbCrSubsampleRatio420,
+			Rect:           rect,
+		}, nil
+	}
+
+	return nil, ErrVideoFormatUnsupported
+}
+
+// RGBA returns a copy of the frame, converted to an RGBA image. Unlike the
+// image returned by the Image method, the result remains valid after the
+// frame is released.
+func (vf *VideoFrame) RGBA() (*image.RGBA, error) {
+	src, err := vf.Image()
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := src.Bounds()
+	img := image.NewRGBA(bounds)
+	if vf.Format.Chroma != VideoChromaRV32 {
+		draw.Draw(img, bounds, src, bounds.Min, draw.Src)
+		return img, nil
+	}
+
+	// Convert packed BGRX pixels.
+	pitch, pix := int(vf.Format.Pitches[0]), vf.Planes[0]
+	for y := 0; y < bounds.Dy(); y++ {
+		srcRow, dstRow := pix[y*pitch:], img.Pix[y*img.Stride:]
+		for x := 0; x < bounds.Dx(); x++ {
+			s, d := srcRow[x*4:x*4+4], dstRow[x*4:x*4+4]
+			d[0], d[1], d[2], d[3] = s[2], s[1], s[0], 0xff
+		}
+	}
+
+	return img, nil
+}
+
+// Release returns the buffer of the frame to the pool of the video sink,
+// in order for it to be reused by subsequent frames. The frame must not
+// be used after it is released.
+func (vf *VideoFrame) Release() {
+	if vf == nil || vf.pool == nil {
+		return
+	}
+
+	vf.pool.Put(vf.buf)
+	vf.buf, vf.pool, vf.Planes = nil, nil, nil
+}
+
+// VideoSink represents a receiver of the decoded video frames of a player.
+// Use the Player.SetVideoSink method in order to redirect the video output
+// of a player to a video sink.
+//
+//	NOTE: The methods of the sink are invoked from the internal threads of
+//	libVLC. They should return as soon as possible and they must not call
+//	libVLC functions.
+type VideoSink interface {
+	// SetupVideo is called when the video output is started. The provided
+	// format contains the chroma and dimensions of the source video. The
+	// sink can change the Chroma, Width and Height fields in order to
+	// request a different format, which is then produced by libVLC.
+	// Returning an error prevents the video from being displayed.
+	SetupVideo(format *VideoFormat) error
+
+	// RenderVideo is called when a frame must be displayed, as determined
+	// by the playback clock. The sink owns the frame and should call its
+	// Release method when it no longer needs it.
+	RenderVideo(frame *VideoFrame)
+
+	// CleanupVideo is called when the video output is stopped.
+	CleanupVideo()
+}
+
+// SetVideoSink redirects the video output of the player to the specified
+// video sink. The sink is used starting with the next video output, so it
+// should be set before the playback is started.
+//
+//	NOTE: Once set, the video sink cannot be removed. A different sink
+//	can be set in order to replace it.
+func (p *Player) SetVideoSink(sink VideoSink) error {
+	if err := p.assertInit(); err != nil {
+		return err
+	}
+	if sink == nil {
+		return ErrInvalidVideoSink
+	}
+
+	// Register video sink context. The player holds a reference to the
+	// context, while each started video output holds an additional one.
+	id := p.inst.objects.add(&videoSinkContext{
+		sink:     sink,
+		pictures: map[unsafe.Pointer]*videoPicture{},
+	})
+	C.videoSetCallbacks(p.player, id)
+
+	// Release previous video sink context.
+	p.inst.objects.decRefs(p.videoSinkID)
+	p.videoSinkID = id
+
+	return nil
+}
+
+type videoPicture struct {
+	buf    unsafe.Pointer
+	planes [3]unsafe.Pointer
+
+	unlocked  bool
+	displayed bool
+}
+
+// videoClock returns the current value of the libVLC clock.
+var videoClock = func() int64 {
+	return int64(C.libvlc_clock())
+}
+
+type videoSinkContext struct {
+	sync.Mutex
+
+	sink   VideoSink
+	format VideoFormat
+
+	frames    *sync.Pool
+	pictures  map[unsafe.Pointer]*videoPicture
+	free      []*videoPicture
+	pending   []*videoPicture
+	latest    *videoPicture
+	snapshots []chan *image.RGBA
+}
+
+func (vc *videoSinkContext) setup(format *VideoFormat) error {
+	if err := vc.sink.SetupVideo(format); err != nil {
+		return err
+	}
+	if err := format.Chroma.Validate(); err != nil {
+		return err
+	}
+	if format.Width == 0 || format.Height == 0 {
+		return ErrInvalid
+	}
+
+	// Compute plane dimensions.
+	width, height := format.Width, format.Height
+	switch format.Chroma {
+	case VideoChromaRV32:
+		format.Pitches = []uint{alignUp(width*4, 32)}
+		format.Lines = []uint{height}
+	case VideoChromaRV24:
+		format.Pitches = []uint{alignUp(width*3, 32)}
+		format.Lines = []uint{height}
+	case VideoChromaI420:
+		pitch, lines := alignUp(width, 32), alignUp(height, 2)
+		format.Pitches = []uint{pitch, pitch / 2, pitch / 2}
+		format.Lines = []uint{lines, lines / 2, lines / 2}
+	}
+
+	vc.Lock()
+	vc.format = *format
+
+	size := format.FrameSize()
+	vc.frames = &sync.Pool{
+		New: func() interface{} {
+			buf := make([]byte, size)
+			return &buf
+		},
+	}
+	vc.Unlock()
+
+	return nil
+}
+
+func (vc *videoSinkContext) lock(planes *[3]unsafe.Pointer) unsafe.Pointer {
+	vc.Lock()
+	defer vc.Unlock()
+
+	// The pending pictures are no longer the most recently locked ones, so
+	// they were dropped by libVLC.
+	vc.free = append(vc.free, vc.pending...)
+	vc.pending = vc.pending[:0]
+
+	// Reuse a previously allocated picture, if available.
+	var picture *videoPicture
+	if count := len(vc.free); count > 0 {
+		picture = vc.free[count-1]
+		vc.free = vc.free[:count-1]
+	} else {
+		buf := C.malloc(C.size_t(vc.format.FrameSize()))
+		if buf == nil {
+			return nil
+		}
+
+		picture = &videoPicture{buf: buf}
+
+		offset := uintptr(0)
+		for i := range vc.format.Pitches {
+			picture.planes[i] = unsafe.Pointer(uintptr(buf) + offset)
+			offset += uintptr(vc.format.Pitches[i] * vc.format.Lines[i])
+		}
+		vc.pictures[buf] = picture
+	}
+	picture.unlocked, picture.displayed = false, false
+	vc.latest = picture
+
+	*planes = picture.planes
+	return picture.buf
+}
+
+func (vc *videoSinkContext) unlock(id unsafe.Pointer) {
+	vc.Lock()
+	defer vc.Unlock()
+
+	picture, ok := vc.pictures[id]
+	if !ok {
+		return
+	}
+	picture.unlocked = true
+
+	// libVLC usually displays pictures before unlocking them. Pictures
+	// which are unlocked without being displayed were dropped, unless they
+	// are the most recently locked ones, which can still be displayed.
+	if picture.displayed || picture != vc.latest {
+		vc.free = append(vc.free, picture)
+		return
+	}
+	vc.pending = append(vc.pending, picture)
+}
+
+func (vc *videoSinkContext) display(id unsafe.Pointer) {
+	clock := videoClock()
+
+	vc.Lock()
+	picture, ok := vc.pictures[id]
+	format, pool := vc.format, vc.frames
+	snapshots := vc.snapshots
+	vc.snapshots = nil
+	vc.Unlock()
+	if !ok || pool == nil {
+		return
+	}
+
+	// Copy picture data to a pooled frame buffer.
+	buf := pool.Get().(*[]byte)
+	data := *buf
+	if len(data) > 0 {
+		C.memcpy(unsafe.Pointer(&data[0]), picture.buf, C.size_t(len(data)))
+	}
+	vc.recycle(picture)
+
+	planes := make([][]byte, len(format.Pitches))
+	for i, offset := 0, 0; i < len(planes); i++ {
+		size := int(format.Pitches[i] * format.Lines[i])
+		planes[i] = data[offset : offset+size : offset+size]
+		offset += size
+	}
+
+	frame := &VideoFrame{
+		Format: format,
+		Clock:  clock,
+		Planes: planes,
+		buf:    buf,
+		pool:   pool,
+	}
+
+	// Deliver frame to pending snapshot requests.
+	if len(snapshots) > 0 {
+		img, _ := frame.RGBA()
+		for _, snapshot := range snapshots {
+			snapshot <- img
+		}
+	}
+
+	vc.sink.RenderVideo(frame)
+}
+
+// recycle makes the specified displayed picture available for reuse. If
+// the picture is not unlocked yet, it is recycled once it is unlocked.
+func (vc *videoSinkContext) recycle(picture *videoPicture) {
+	vc.Lock()
+	defer vc.Unlock()
+
+	if !picture.unlocked {
+		picture.displayed = true
+		return
+	}
+
+	for i, pending := range vc.pending {
+		if pending == picture {
+			vc.pending = append(vc.pending[:i], vc.pending[i+1:]...)
+			vc.free = append(vc.free, picture)
+			return
+		}
+	}
+}
+
+// snapshot registers a request for a copy of the next displayed frame.
+// The returned channel receives the frame, or nil if it cannot be
+// converted to an image.
+func (vc *videoSinkContext) snapshot() chan *image.RGBA {
+	snapshot := make(chan *image.RGBA, 1)
+
+	vc.Lock()
+	vc.snapshots = append(vc.snapshots, snapshot)
+	vc.Unlock()
+
+	return snapshot
+}
+
+func (vc *videoSinkContext) cancelSnapshot(snapshot chan *image.RGBA) {
+	vc.Lock()
+	defer vc.Unlock()
+
+	for i, s := range vc.snapshots {
+		if s == snapshot {
+			vc.snapshots = append(vc.snapshots[:i], vc.snapshots[i+1:]...)
+			return
+		}
+	}
+}
+
+func (vc *videoSinkContext) cleanup() {
+	vc.Lock()
+	for id := range vc.pictures {
+		C.free(id)
+	}
+	vc.pictures = map[unsafe.Pointer]*videoPicture{}
+	vc.free, vc.pending, vc.latest, vc.frames = nil, nil, nil, nil
+	vc.Unlock()
+
+	vc.sink.CleanupVideo()
+}
+
+func getVideoSinkContext(id objectID) (*Instance, *videoSinkContext, bool) {
+	inst, obj, ok := instances.object(id)
+	if !ok {
+		return nil, nil, false
+	}
+
+	ctx, ok := obj.(*videoSinkContext)
+	return inst, ctx, ok
+}
+
+func alignUp(value, alignment uint) uint {
+	return (value + alignment - 1) / alignment * alignment
+}
+
+// bgrImage is an image backed by packed RGB pixel data, stored in
+// B, G, R byte order.
+type bgrImage struct {
+	pix    []byte
+	stride int
+	bpp    int
+	rect   image.Rectangle
+}
+
+func (img *bgrImage) ColorModel() color.Model {
+	return color.RGBAModel
+}
+
+func (img *bgrImage) Bounds() image.Rectangle {
+	return img.rect
+}
+
+func (img *bgrImage) At(x, y int) color.Color {
+	if !(image.Point{X: x, Y: y}.In(img.rect)) {
+		return color.RGBA{}
+	}
+
+	i := y*img.stride + x*img.bpp
+	return color.RGBA{R: img.pix[i+2], G: img.pix[i+1], B: img.pix[i], A: 0xff}
+}
+
+//export videoFormatCB
+func videoFormatCB(opaque *unsafe.Pointer, chroma *C.char, width, height, pitches, lines *C.uint) C.uint {
+	inst, ctx, ok := getVideoSinkContext(*opaque)
+	if !ok {
+		return 0
+	}
+
+	// Negotiate video format.
+	format := &VideoFormat{
+		Chroma: VideoChroma(C.GoStringN(chroma, 4)),
+		Width:  uint(*width),
+		Height: uint(*height),
+	}
+	if err := ctx.setup(format); err != nil {
+		return 0
+	}
+
+	cChroma := C.CString(string(format.Chroma))
+	C.memcpy(unsafe.Pointer(chroma), unsafe.Pointer(cChroma), 4)
+	C.free(unsafe.Pointer(cChroma))
+
+	*width, *height = C.uint(format.Width), C.uint(format.Height)
+
+	cPitches := (*[3]C.uint)(unsafe.Pointer(pitches))
+	cLines := (*[3]C.uint)(unsafe.Pointer(lines))
+	for i := range format.Pitches {
+		cPitches[i], cLines[i] = C.uint(format.Pitches[i]), C.uint(format.Lines[i])
+	}
+
+	// The video output holds a reference to the context until cleanup.
+	inst.objects.incRefs(*opaque)
+	return 1
+}
+
+//export videoCleanupCB
+func videoCleanupCB(opaque unsafe.Pointer) {
+	inst, ctx, ok := getVideoSinkContext(opaque)
+	if !ok {
+		return
+	}
+
+	ctx.cleanup()
+	inst.objects.decRefs(opaque)
+}
+
+//export videoLockCB
+func videoLockCB(opaque unsafe.Pointer, planes *unsafe.Pointer) unsafe.Pointer {
+	_, ctx, ok := getVideoSinkContext(opaque)
+	if !ok {
+		return nil
+	}
+
+	return ctx.lock((*[3]unsafe.Pointer)(unsafe.Pointer(planes)))
+}
+
+//export videoUnlockCB
+func videoUnlockCB(opaque, picture unsafe.Pointer, planes *unsafe.Pointer) {
+	if _, ctx, ok := getVideoSinkContext(opaque); ok {
+		ctx.unlock(picture)
+	}
+}
+
+//export videoDisplayCB
+func videoDisplayCB(opaque, picture unsafe.Pointer) {
+	if _, ctx, ok := getVideoSinkContext(opaque); ok {
+		ctx.display(picture)
+	}
+}
@@ -0,0 +1,116 @@
+package vlc
+
+import (
+	"testing"
+	"unsafe"
+)
+
+type testVideoSink struct {
+	frames []*VideoFrame
+}
+
+func (s *testVideoSink) SetupVideo(format *VideoFormat) error { return nil }
+func (s *testVideoSink) RenderVideo(frame *VideoFrame)        { s.frames = append(s.frames, frame) }
+func (s *testVideoSink) CleanupVideo()                        {}
+
+func TestVideoSinkPictureRecycling(t *testing.T) {
+	clock := videoClock
+	defer func() { videoClock = clock }()
+
+	var now int64
+	videoClock = func() int64 {
+		now += 40000
+		return now
+	}
+
+	// Each step locks a new picture (l), unlocks (u) or displays (d) the
+	// picture locked by the specified step.
+	type step struct {
+		op    byte
+		frame int
+	}
+
+	tests := []struct {
+		name      string
+		steps     []step
+		displayed int
+		allocated int
+		free      int
+	}{
+		{
+			name:      "display before unlock",
+			steps:     []step{{'l', 0}, {'d', 0}, {'u', 0}, {'l', 1}, {'d', 1}, {'u', 1}},
+			displayed: 2, allocated: 1, free: 1,
+		},
+		{
+			name:      "unlock before display",
+			steps:     []step{{'l', 0}, {'u', 0}, {'d', 0}, {'l', 1}, {'u', 1}, {'d', 1}},
+			displayed: 2, allocated: 1, free: 1,
+		},
+		{
+			name:      "dropped before unlock",
+			steps:     []step{{'l', 0}, {'l', 1}, {'u', 0}, {'d', 1}, {'u', 1}, {'l', 2}},
+			displayed: 1, allocated: 2, free: 1,
+		},
+		{
+			name:      "dropped most recent picture",
+			steps:     []step{{'l', 0}, {'u', 0}, {'l', 1}, {'d', 1}, {'u', 1}},
+			displayed: 1, allocated: 1, free: 1,
+		},
+		{
+			name: "dropped every other picture",
+			steps: []step{
+				{'l', 0}, {'u', 0}, {'l', 1}, {'d', 1}, {'u', 1},
+				{'l', 2}, {'u', 2}, {'l', 3}, {'d', 3}, {'u', 3},
+				{'l', 4}, {'u', 4}, {'l', 5}, {'d', 5}, {'u', 5},
+			},
+			displayed: 3, allocated: 1, free: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sink := &testVideoSink{}
+			vc := &videoSinkContext{
+				sink:     sink,
+				pictures: map[unsafe.Pointer]*videoPicture{},
+			}
+			if err := vc.setup(&VideoFormat{Chroma: VideoChromaRV32, Width: 4, Height: 2}); err != nil {
+				t.Fatal(err)
+			}
+			defer vc.cleanup()
+
+			ids := map[int]unsafe.Pointer{}
+			for _, step := range test.steps {
+				switch step.op {
+				case 'l':
+					var planes [3]unsafe.Pointer
+					if ids[step.frame] = vc.lock(&planes); ids[step.frame] == nil {
+						t.Fatal("could not lock picture")
+					}
+				case 'u':
+					vc.unlock(ids[step.frame])
+				case 'd':
+					vc.display(ids[step.frame])
+				}
+			}
+
+			if len(sink.frames) != test.displayed {
+				t.Errorf("displayed frames: got %d, want %d", len(sink.frames), test.displayed)
+			}
+			for i, frame := range sink.frames {
+				if i > 0 && frame.Clock <= sink.frames[i-1].Clock {
+					t.Errorf("frame %d clock: got %d, previous %d", i, frame.Clock, sink.frames[i-1].Clock)
+				}
+				frame.Release()
+			}
+
+			if len(vc.pictures) != test.allocated {
+				t.Errorf("allocated pictures: got %d, want %d", len(vc.pictures), test.allocated)
+			}
+			if len(vc.free) != test.free {
+				t.Errorf("free pictures: got %d, want %d", len(vc.free), test.free)
+			}
+		})
+	}
+}
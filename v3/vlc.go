@@ -6,33 +6,50 @@ package vlc
 // #include <stdlib.h>
 import "C"
 import (
+	"sync"
 	"unsafe"
 )
 
-type instance struct {
-	handle  *C.libvlc_instance_t
-	events  *eventRegistry
-	objects *objectRegistry
-}
+// Instance represents a libVLC instance. Each instance has its own
+// configuration, specified through command line arguments when the instance
+// is created, and its own set of registered events and objects. This allows
+// multiple players using different settings (e.g. `--aout`, `--vout`,
+// `--network-caching`) to be used in the same process.
+//
+// The package level functions (e.g. NewPlayer, NewMediaFromPath) use the
+// default instance, which is created by the Init function.
+type Instance struct {
+	handle      *C.libvlc_instance_t
+	events      *eventRegistry
+	objects     *objectRegistry
+	dispatchers *eventDispatcherRegistry
+	players     *playerStateRegistry
 
-func (i *instance) assertInit() error {
-	if i == nil || i.handle == nil {
-		return ErrModuleNotInitialized
-	}
+	logMu    sync.Mutex
+	loggerID objectID
 
-	return nil
-}
+	dialogMu        sync.Mutex
+	dialogHandler   DialogHandler
+	dialogHandlerID objectID
+	dialogs         *dialogRegistry
+	credentials     *mediaCredentialRegistry
 
-var inst *instance
+	vlmMu sync.Mutex
+	vlm   *VLM
 
-// Init creates an instance of the libVLC module.
-// Must be called only once and the module instance must be released using
-// the Release function.
-func Init(args ...string) error {
-	if inst != nil {
-		return nil
-	}
+	sidecarMu    sync.Mutex
+	sidecarRules *SidecarRules
+
+	releaseMu sync.Mutex
+	exit      *instanceExit
+}
 
+// NewInstance creates a new libVLC instance, configured using the
+// specified command line arguments.
+//
+//	NOTE: Call the Release method on the instance in order to free the
+//	allocated resources.
+func NewInstance(args ...string) (*Instance, error) {
 	argc := len(args)
 	argv := make([]*C.char, argc)
 
@@ -47,57 +64,101 @@ func Init(args ...string) error {
 
 	handle := C.libvlc_new(C.int(argc), *(***C.char)(unsafe.Pointer(&argv)))
 	if handle == nil {
-		return errOrDefault(getError(), ErrModuleInitialize)
+		return nil, errOrDefault(getError(), ErrModuleInitialize)
 	}
 
-	inst = &instance{
-		handle:  handle,
-		events:  newEventRegistry(),
-		objects: newObjectRegistry(),
+	i := &Instance{
+		handle:      handle,
+		events:      newEventRegistry(),
+		objects:     newObjectRegistry(),
+		dispatchers: newEventDispatcherRegistry(),
+		players:     newPlayerStateRegistry(),
+		dialogs:     newDialogRegistry(),
+		credentials: newMediaCredentialRegistry(),
+		exit:        newInstanceExit(),
 	}
+	instances.add(i)
+	i.setExitHandler()
 
-	return nil
+	return i, nil
 }
 
-// Release destroys the instance created by the Init function.
-func Release() error {
-	if inst == nil {
+// Release destroys the libVLC instance. The objects created using the
+// instance should be released before calling this method. The method can
+// be called multiple times, including concurrently and after the instance
+// is requested to exit. The channel returned by the Done method is closed
+// once the instance is released.
+func (i *Instance) Release() error {
+	if i == nil {
 		return nil
 	}
 
-	C.libvlc_release(inst.handle)
-	inst = nil
-	return nil
-}
+	i.releaseMu.Lock()
+	defer i.releaseMu.Unlock()
 
-// Version returns details regarding the version of the libVLC module.
-func Version() VersionInfo {
-	return moduleVersion
+	if err := i.assertInit(); err != nil {
+		return nil
+	}
+
+	// Remove logger, if one is set.
+	i.logMu.Lock()
+	i.unsetLogger()
+	i.logMu.Unlock()
+
+	// Remove dialog handler, if one is set.
+	i.dialogMu.Lock()
+	i.unsetDialogHandler()
+	i.dialogHandler = nil
+	i.dialogMu.Unlock()
+
+	// Remove exit handler.
+	i.unsetExitHandler()
+
+	// Release the VLM, if it is used.
+	i.vlmMu.Lock()
+	i.releaseVLM()
+	i.vlmMu.Unlock()
+
+	// Stop asynchronous event dispatchers.
+	i.dispatchers.stopAll()
+
+	// Notify the registered events, in order to close event subscriptions.
+	for _, ctx := range i.events.removeAll() {
+		if ctx.detached != nil {
+			ctx.detached()
+		}
+	}
+
+	instances.remove(i)
+	C.libvlc_release(i.handle)
+	i.handle = nil
+
+	return nil
 }
 
-// SetAppName sets the human-readable application name and the HTTP user agent.
-// The specified user agent is used when a protocol requires it.
-func SetAppName(name, userAgent string) error {
-	if err := inst.assertInit(); err != nil {
+// SetAppName sets the human-readable application name and the HTTP user
+// agent used by the instance.
+func (i *Instance) SetAppName(name, userAgent string) error {
+	if err := i.assertInit(); err != nil {
 		return err
 	}
 
 	cName, cUserAgent := C.CString(name), C.CString(userAgent)
-	C.libvlc_set_user_agent(inst.handle, cName, cUserAgent)
+	C.libvlc_set_user_agent(i.handle, cName, cUserAgent)
 
 	C.free(unsafe.Pointer(cName))
 	C.free(unsafe.Pointer(cUserAgent))
 	return nil
 }
 
-// SetAppID sets metadata for identifying the application.
-func SetAppID(id, version, icon string) error {
-	if err := inst.assertInit(); err != nil {
+// SetAppID sets metadata for identifying the application using the instance.
+func (i *Instance) SetAppID(id, version, icon string) error {
+	if err := i.assertInit(); err != nil {
 		return err
 	}
 
 	cID, cVersion, cIcon := C.CString(id), C.CString(version), C.CString(icon)
-	C.libvlc_set_app_id(inst.handle, cID, cVersion, cIcon)
+	C.libvlc_set_app_id(i.handle, cID, cVersion, cIcon)
 
 	C.free(unsafe.Pointer(cID))
 	C.free(unsafe.Pointer(cVersion))
@@ -105,20 +166,142 @@ func SetAppID(id, version, icon string) error {
 	return nil
 }
 
-// StartUserInterface attempts to start a user interface for the libVLC
-// instance. Pass an empty string as the name parameter in order to start
-// the default interface.
-func StartUserInterface(name string) error {
-	if err := inst.assertInit(); err != nil {
+// StartUserInterface attempts to start a user interface for the instance.
+// Pass an empty string as the name parameter in order to start the
+// default interface.
+func (i *Instance) StartUserInterface(name string) error {
+	if err := i.assertInit(); err != nil {
 		return err
 	}
 
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cName))
 
-	if C.libvlc_add_intf(inst.handle, cName) < 0 {
+	if C.libvlc_add_intf(i.handle, cName) < 0 {
 		return errOrDefault(getError(), ErrUserInterfaceStart)
 	}
 
 	return nil
 }
+
+func (i *Instance) assertInit() error {
+	if i == nil || i.handle == nil {
+		return ErrModuleNotInitialized
+	}
+
+	return nil
+}
+
+var inst *Instance
+
+// Init creates the default instance of the libVLC module, which is used by
+// the package level functions. Must be called only once and the module
+// instance must be released using the Release function.
+func Init(args ...string) error {
+	if inst != nil {
+		return nil
+	}
+
+	i, err := NewInstance(args...)
+	if err != nil {
+		return err
+	}
+
+	inst = i
+	return nil
+}
+
+// Release destroys the instance created by the Init function.
+func Release() error {
+	if inst == nil {
+		return nil
+	}
+
+	err := inst.Release()
+	inst = nil
+	return err
+}
+
+// DefaultInstance returns the default instance of the libVLC module, created
+// by the Init function, or `nil` if the module is not initialized.
+func DefaultInstance() *Instance {
+	return inst
+}
+
+// Version returns details regarding the version of the libVLC module.
+func Version() VersionInfo {
+	return moduleVersion
+}
+
+// SetAppName sets the human-readable application name and the HTTP user agent.
+// The specified user agent is used when a protocol requires it.
+func SetAppName(name, userAgent string) error {
+	return inst.SetAppName(name, userAgent)
+}
+
+// SetAppID sets metadata for identifying the application.
+func SetAppID(id, version, icon string) error {
+	return inst.SetAppID(id, version, icon)
+}
+
+// StartUserInterface attempts to start a user interface for the libVLC
+// instance. Pass an empty string as the name parameter in order to start
+// the default interface.
+func StartUserInterface(name string) error {
+	return inst.StartUserInterface(name)
+}
+
+// instanceRegistry keeps track of the active libVLC instances. It is used
+// by the libVLC callbacks in order to find the instance owning a registered
+// event or object.
+type instanceRegistry struct {
+	sync.RWMutex
+
+	instances []*Instance
+}
+
+var instances = &instanceRegistry{}
+
+func (ir *instanceRegistry) add(i *Instance) {
+	ir.Lock()
+	ir.instances = append(ir.instances, i)
+	ir.Unlock()
+}
+
+func (ir *instanceRegistry) remove(i *Instance) {
+	ir.Lock()
+	defer ir.Unlock()
+
+	for idx, instance := range ir.instances {
+		if instance == i {
+			ir.instances = append(ir.instances[:idx], ir.instances[idx+1:]...)
+			return
+		}
+	}
+}
+
+func (ir *instanceRegistry) event(id EventID) (*Instance, *eventContext, bool) {
+	ir.RLock()
+	defer ir.RUnlock()
+
+	for _, i := range ir.instances {
+		if ctx, ok := i.events.get(id); ok {
+			return i, ctx, true
+		}
+	}
+
+	return nil, nil, false
+}
+
+func (ir *instanceRegistry) object(id objectID) (*Instance, interface{}, bool) {
+	ir.RLock()
+	defer ir.RUnlock()
+
+	for _, i := range ir.instances {
+		if data, ok := i.objects.get(id); ok {
+			return i, data, true
+		}
+	}
+
+	return nil, nil, false
+}
@@ -0,0 +1,36 @@
+package vlc
+
+import (
+	"unsafe"
+)
+
+// testHandles provides the memory referenced by the fake libVLC handles
+// used by the tests which do not call libVLC functions.
+var testHandles [16]uint64
+
+// newTestInstance returns an instance with a fake libVLC handle, which can
+// be used by tests which only exercise the bindings (e.g. event emission).
+func newTestInstance() *Instance {
+	return &Instance{
+		handle:      (*_Ctype_libvlc_instance_t)(unsafe.Pointer(&testHandles[0])),
+		events:      newEventRegistry(),
+		objects:     newObjectRegistry(),
+		dispatchers: newEventDispatcherRegistry(),
+		players:     newPlayerStateRegistry(),
+		dialogs:     newDialogRegistry(),
+		credentials: newMediaCredentialRegistry(),
+		exit:        newInstanceExit(),
+	}
+}
+
+// newTestPlayer returns a player with a fake libVLC handle, along with its
+// event manager.
+func newTestPlayer(inst *Instance) (*Player, *EventManager) {
+	player := &Player{
+		player: (*_Ctype_libvlc_media_player_t)(unsafe.Pointer(&testHandles[1])),
+		inst:   inst,
+	}
+	manager := newEventManager(inst, (*_Ctype_libvlc_event_manager_t)(unsafe.Pointer(&testHandles[2])))
+
+	return player, manager
+}
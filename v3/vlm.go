@@ -0,0 +1,311 @@
+package vlc
+
+// #cgo LDFLAGS: -lvlc
+// #include <vlc/vlc.h>
+// #include <stdlib.h>
+import "C"
+import (
+	"unsafe"
+)
+
+// VLM represents the VideoLAN Manager of an instance, which manages named
+// broadcast and video on demand (VOD) media. Each broadcast media reads
+// its inputs and streams them to its output, independently of players,
+// which allows running multiple broadcast channels from one process.
+// The outputs are specified using stream output chains
+// (e.g. `#std{access=http,mux=ts,dst=:8080/channel}`), which can be
+// created using the StreamOutput type.
+type VLM struct {
+	inst *Instance
+}
+
+// NewVLM returns the VideoLAN Manager of the default instance.
+//
+//	NOTE: Each instance has a single VLM, so the same VLM is returned until
+//	it is released. Call the Release method on the VLM in order to stop and
+//	remove all of its media. The VLM is released automatically when its
+//	instance is released.
+func NewVLM() (*VLM, error) {
+	return inst.NewVLM()
+}
+
+// NewVLM returns the VideoLAN Manager of the instance. The same VLM is
+// returned until it is released.
+func (i *Instance) NewVLM() (*VLM, error) {
+	if err := i.assertInit(); err != nil {
+		return nil, err
+	}
+
+	i.vlmMu.Lock()
+	defer i.vlmMu.Unlock()
+
+	if i.vlm == nil {
+		i.vlm = &VLM{inst: i}
+	}
+
+	return i.vlm, nil
+}
+
+// Release stops and removes all the media of the VLM and releases it.
+// The VLM is shared by all the callers of the NewVLM method of the
+// instance, so it becomes unusable for all of them.
+func (v *VLM) Release() error {
+	if err := v.assertInit(); err != nil {
+		return nil
+	}
+
+	v.inst.vlmMu.Lock()
+	defer v.inst.vlmMu.Unlock()
+
+	if v.inst.vlm == v {
+		v.inst.releaseVLM()
+	}
+
+	return nil
+}
+
+// releaseVLM releases the VLM of the instance, if it is used. The caller
+// must hold the VLM lock of the instance.
+func (i *Instance) releaseVLM() {
+	if i.vlm == nil {
+		return
+	}
+
+	i.detachEvents(C.libvlc_vlm_get_event_manager(i.handle))
+	C.libvlc_vlm_release(i.handle)
+	i.vlm = nil
+}
+
+// AddBroadcast adds a broadcast media with the specified name, which
+// streams the specified input to the specified output. The options are
+// applied to the input (e.g. `:input-repeat=2`). If enabled is false, the
+// media cannot be played until it is enabled using the SetEnabled method.
+// If loop is true, the input is played in a loop.
+func (v *VLM) AddBroadcast(name, input, output string, options []string, enabled, loop bool) error {
+	if err := v.assertInit(); err != nil {
+		return err
+	}
+
+	cName, cInput, cOutput := C.CString(name), C.CString(input), C.CString(output)
+	defer C.free(unsafe.Pointer(cName))
+	defer C.free(unsafe.Pointer(cInput))
+	defer C.free(unsafe.Pointer(cOutput))
+
+	cOptions, count, free := vlmOptions(options)
+	defer free()
+
+	return vlmResult(C.libvlc_vlm_add_broadcast(v.inst.handle, cName, cInput, cOutput,
+		count, cOptions, C.int(boolToInt(enabled)), C.int(boolToInt(loop))))
+}
+
+// AddVOD adds a video on demand media with the specified name, which
+// serves the specified input, using the specified muxer (e.g. ts). The VOD
+// media are served by the RTSP server of the instance, which is configured
+// using the `--rtsp-host` instance option. The options are applied to the
+// input. If enabled is false, the media cannot be played until it is
+// enabled using the SetEnabled method.
+func (v *VLM) AddVOD(name, input string, options []string, enabled bool, mux string) error {
+	if err := v.assertInit(); err != nil {
+		return err
+	}
+
+	cName, cInput := C.CString(name), C.CString(input)
+	defer C.free(unsafe.Pointer(cName))
+	defer C.free(unsafe.Pointer(cInput))
+
+	var cMux *C.char
+	if mux != "" {
+		cMux = C.CString(mux)
+		defer C.free(unsafe.Pointer(cMux))
+	}
+
+	cOptions, count, free := vlmOptions(options)
+	defer free()
+
+	return vlmResult(C.libvlc_vlm_add_vod(v.inst.handle, cName, cInput,
+		count, cOptions, C.int(boolToInt(enabled)), cMux))
+}
+
+// DeleteMedia stops and removes the media with the specified name.
+func (v *VLM) DeleteMedia(name string) error {
+	return v.mediaCommand(name, func(cName *C.char) C.int {
+		return C.libvlc_vlm_del_media(v.inst.handle, cName)
+	})
+}
+
+// SetEnabled enables or disables the media with the specified name.
+func (v *VLM) SetEnabled(name string, enabled bool) error {
+	return v.mediaCommand(name, func(cName *C.char) C.int {
+		return C.libvlc_vlm_set_enabled(v.inst.handle, cName, C.int(boolToInt(enabled)))
+	})
+}
+
+// SetOutput sets the output of the media with the specified name.
+func (v *VLM) SetOutput(name, output string) error {
+	return v.mediaStringCommand(name, output, func(cName, cValue *C.char) C.int {
+		return C.libvlc_vlm_set_output(v.inst.handle, cName, cValue)
+	})
+}
+
+// SetInput replaces the inputs of the media with the specified name with
+// the specified input.
+func (v *VLM) SetInput(name, input string) error {
+	return v.mediaStringCommand(name, input, func(cName, cValue *C.char) C.int {
+		return C.libvlc_vlm_set_input(v.inst.handle, cName, cValue)
+	})
+}
+
+// AddInput adds the specified input to the media with the specified name.
+// The inputs of a media are played one after the other.
+func (v *VLM) AddInput(name, input string) error {
+	return v.mediaStringCommand(name, input, func(cName, cValue *C.char) C.int {
+		return C.libvlc_vlm_add_input(v.inst.handle, cName, cValue)
+	})
+}
+
+// SetLoop sets whether the inputs of the broadcast media with the
+// specified name are played in a loop.
+func (v *VLM) SetLoop(name string, loop bool) error {
+	return v.mediaCommand(name, func(cName *C.char) C.int {
+		return C.libvlc_vlm_set_loop(v.inst.handle, cName, C.int(boolToInt(loop)))
+	})
+}
+
+// SetMux sets the muxer of the VOD media with the specified name.
+func (v *VLM) SetMux(name, mux string) error {
+	return v.mediaStringCommand(name, mux, func(cName, cValue *C.char) C.int {
+		return C.libvlc_vlm_set_mux(v.inst.handle, cName, cValue)
+	})
+}
+
+// Play starts playing the media with the specified name.
+func (v *VLM) Play(name string) error {
+	return v.mediaCommand(name, func(cName *C.char) C.int {
+		return C.libvlc_vlm_play_media(v.inst.handle, cName)
+	})
+}
+
+// Stop stops playing the media with the specified name.
+func (v *VLM) Stop(name string) error {
+	return v.mediaCommand(name, func(cName *C.char) C.int {
+		return C.libvlc_vlm_stop_media(v.inst.handle, cName)
+	})
+}
+
+// Pause pauses the media with the specified name.
+func (v *VLM) Pause(name string) error {
+	return v.mediaCommand(name, func(cName *C.char) C.int {
+		return C.libvlc_vlm_pause_media(v.inst.handle, cName)
+	})
+}
+
+// Seek seeks the media with the specified name to the specified position,
+// as a percentage of the media length, in the [0.0, 100.0] interval.
+func (v *VLM) Seek(name string, percentage float32) error {
+	if percentage < 0 || percentage > 100 {
+		return ErrInvalid
+	}
+
+	return v.mediaCommand(name, func(cName *C.char) C.int {
+		return C.libvlc_vlm_seek_media(v.inst.handle, cName, C.float(percentage))
+	})
+}
+
+// ShowMedia returns information about the media with the specified name,
+// in JSON format. If the name is empty, information about all the media
+// of the VLM is returned.
+func (v *VLM) ShowMedia(name string) (string, error) {
+	if err := v.assertInit(); err != nil {
+		return "", err
+	}
+
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	cInfo := C.libvlc_vlm_show_media(v.inst.handle, cName)
+	if cInfo == nil {
+		return "", errOrDefault(getError(), ErrVLMCommand)
+	}
+	defer C.free(unsafe.Pointer(cInfo))
+
+	return C.GoString(cInfo), nil
+}
+
+// EventManager returns the event manager responsible for the VLM. The
+// events of the VLM are VlmMediaAdded through VlmMediaInstanceStatusError,
+// and their payload is a *VlmPayload.
+func (v *VLM) EventManager() (*EventManager, error) {
+	if err := v.assertInit(); err != nil {
+		return nil, err
+	}
+
+	manager := C.libvlc_vlm_get_event_manager(v.inst.handle)
+	if manager == nil {
+		return nil, ErrMissingEventManager
+	}
+
+	return newEventManager(v.inst, manager), nil
+}
+
+func (v *VLM) assertInit() error {
+	if v == nil || v.inst == nil {
+		return ErrVLMNotInitialized
+	}
+
+	v.inst.vlmMu.Lock()
+	active := v.inst.vlm == v
+	v.inst.vlmMu.Unlock()
+	if !active {
+		return ErrVLMNotInitialized
+	}
+
+	return v.inst.assertInit()
+}
+
+func (v *VLM) mediaCommand(name string, fn func(cName *C.char) C.int) error {
+	if err := v.assertInit(); err != nil {
+		return err
+	}
+
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	return vlmResult(fn(cName))
+}
+
+func (v *VLM) mediaStringCommand(name, value string, fn func(cName, cValue *C.char) C.int) error {
+	cValue := C.CString(value)
+	defer C.free(unsafe.Pointer(cValue))
+
+	return v.mediaCommand(name, func(cName *C.char) C.int {
+		return fn(cName, cValue)
+	})
+}
+
+func vlmOptions(options []string) (**C.char, C.int, func()) {
+	if len(options) == 0 {
+		return nil, 0, func() {}
+	}
+
+	// Allocate the array in C memory, as it contains C pointers.
+	size := C.size_t(len(options)) * C.size_t(unsafe.Sizeof((*C.char)(nil)))
+	cOptions := (*[1 << 28]*C.char)(C.malloc(size))[:len(options):len(options)]
+	for i, option := range options {
+		cOptions[i] = C.CString(option)
+	}
+
+	return &cOptions[0], C.int(len(options)), func() {
+		for _, cOption := range cOptions {
+			C.free(unsafe.Pointer(cOption))
+		}
+		C.free(unsafe.Pointer(&cOptions[0]))
+	}
+}
+
+func vlmResult(result C.int) error {
+	if result != 0 {
+		return errOrDefault(getError(), ErrVLMCommand)
+	}
+
+	return nil
+}